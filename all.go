@@ -0,0 +1,27 @@
+//go:build go1.23
+
+package zax
+
+import (
+	"context"
+	"iter"
+
+	"go.uber.org/zap"
+)
+
+// All returns an iterator over the fields stored in ctx, newest first.
+func All(ctx context.Context) iter.Seq[zap.Field] {
+	return defaultStore.All(ctx)
+}
+
+// All returns an iterator over the fields of s in ctx; see [All].
+func (s *Store) All(ctx context.Context) iter.Seq[zap.Field] {
+	return func(yield func(zap.Field) bool) {
+		s.Range(ctx, yield)
+	}
+}
+
+// All returns an iterator over the fields of v, newest first.
+func (v FieldView) All() iter.Seq[zap.Field] {
+	return v.Range
+}
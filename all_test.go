@@ -0,0 +1,38 @@
+//go:build go1.23 && !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAll(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String(spanIDKey, "span")})
+
+	var keys []string
+	for field := range All(ctx) {
+		keys = append(keys, field.Key)
+		if field.Key == traceIDKey {
+			break
+		}
+	}
+	assert.Equal(t, []string{traceIDKey}, keys)
+
+	for range All(context.Background()) {
+		t.Fatal("unexpected field")
+	}
+}
+
+func TestFieldViewAll(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String(spanIDKey, "span")})
+
+	var keys []string
+	for field := range View(ctx).All() {
+		keys = append(keys, field.Key)
+	}
+	assert.Equal(t, []string{traceIDKey, spanIDKey}, keys)
+}
@@ -0,0 +1,88 @@
+// Package audit records structured audit events, combining mandatory zax
+// context fields, such as the actor and tenant, with the data of each event.
+package audit
+
+import (
+	"context"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields of audit events.
+const (
+	// MarkerKey marks audit entries, to route them with [zax.NewRouter].
+	MarkerKey  = "audit"
+	ActionKey  = "action"
+	SubjectKey = "subject"
+	OutcomeKey = "outcome"
+	// ActorKey is the context field holding who performed the action.
+	ActorKey = "actor"
+)
+
+// Message is the message of audit entries.
+const Message = "audit"
+
+// Outcome is the result of an audited action.
+type Outcome string
+
+// Common outcomes.
+const (
+	Success Outcome = "success"
+	Failure Outcome = "failure"
+	Denied  Outcome = "denied"
+)
+
+// DefaultRequired are the context fields required by default: the actor, the
+// tenant and the trace.
+var DefaultRequired = []string{ActorKey, zax.TenantIDKey.Name(), zax.TraceIDKey.Name()}
+
+// Auditor records audit events through a logger.
+type Auditor struct {
+	logger   *zap.Logger
+	required []string
+}
+
+// New returns an auditor logging through logger, or zap's global logger if
+// nil, requiring the context fields with required keys, or
+// [DefaultRequired] if none are given.
+func New(logger *zap.Logger, required ...string) *Auditor {
+	if len(required) == 0 {
+		required = DefaultRequired
+	}
+	return &Auditor{logger: logger, required: required}
+}
+
+var std = New(nil)
+
+// Record records an event with the default auditor, which requires
+// [DefaultRequired] and logs through zap's global logger; see
+// [Auditor.Record].
+func Record(ctx context.Context, action, subject string, outcome Outcome, fields ...zap.Field) error {
+	return std.Record(ctx, action, subject, outcome, fields...)
+}
+
+// Record logs an audit event: actor performed action on subject, with
+// outcome. The entry carries the fields of ctx along with fields. If any
+// required field is missing from ctx, nothing is logged and a
+// [*zax.MissingFieldsError] is returned, so that audit trails never miss who
+// did what. For the same reason, unlike with [zax.Logger], sampling set with
+// [zax.WithHashSampling] doesn't apply.
+func (a *Auditor) Record(ctx context.Context, action, subject string, outcome Outcome, fields ...zap.Field) error {
+	if _, err := zax.RequireFields(ctx, a.required...); err != nil {
+		return err
+	}
+	logger := a.logger
+	if logger == nil {
+		logger = zap.L()
+	}
+	event := make([]zap.Field, 0, len(fields)+4)
+	event = append(event,
+		zap.Bool(MarkerKey, true),
+		zap.String(ActionKey, action),
+		zap.String(SubjectKey, subject),
+		zap.String(OutcomeKey, string(outcome)),
+	)
+	logger.With(zax.GetAll(ctx)...).Info(Message, append(event, fields...)...)
+	return nil
+}
@@ -0,0 +1,72 @@
+//go:build !zax_disabled
+
+package audit
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+	"go.uber.org/zap"
+)
+
+func TestRecord(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	auditor := New(logs.GetZapLogger())
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String(ActorKey, "alice"), zap.String("trace_id", "trace")})
+	var missing *zax.MissingFieldsError
+	require.ErrorAs(t, auditor.Record(ctx, "delete", "invoice/42", Success), &missing)
+	assert.Equal(t, []string{"tenant_id"}, missing.Keys)
+	assert.Empty(t, logs.GetRecordedLogs())
+
+	ctx = zax.SetTenant(ctx, "acme")
+	require.NoError(t, auditor.Record(ctx, "delete", "invoice/42", Denied, zap.String("reason", "locked")))
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, Message, entries[0].Message)
+	assert.Equal(t, map[string]interface{}{
+		MarkerKey:   true,
+		ActionKey:   "delete",
+		SubjectKey:  "invoice/42",
+		OutcomeKey:  "denied",
+		"reason":    "locked",
+		ActorKey:    "alice",
+		"tenant_id": "acme",
+		"trace_id":  "trace",
+	}, entries[0].ContextMap())
+}
+
+func TestRecordRequired(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	auditor := New(logs.GetZapLogger(), ActorKey)
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String(ActorKey, "alice")})
+	require.NoError(t, auditor.Record(ctx, "login", "session", Success))
+	assert.Len(t, logs.GetRecordedLogs(), 1)
+}
+
+func TestRecordDefault(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	defer zap.ReplaceGlobals(logs.GetZapLogger())()
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String(ActorKey, "alice"), zap.String("tenant_id", "acme"), zap.String("trace_id", "trace")})
+	require.NoError(t, Record(ctx, "login", "session", Failure))
+	assert.Len(t, logs.GetRecordedLogs(), 1)
+}
+
+func TestRecordWithSampling(t *testing.T) {
+	t.Cleanup(func() { zax.Configure(zax.WithHashSampling("user_id", 1)) })
+	zax.Configure(zax.WithHashSampling("user_id", 0))
+	logs := zaxtest.NewLogger(t)
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String(ActorKey, "alice"), zap.String("user_id", "u-7")})
+	require.NoError(t, New(logs.GetZapLogger(), ActorKey).Record(ctx, "login", "session", Success))
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1, "audit events are never sampled out")
+	assert.Equal(t, "u-7", entries[0].ContextMap()["user_id"])
+}
@@ -0,0 +1,136 @@
+package zax
+
+import (
+	"context"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// bagKey is the context key of the bag stored by [WithBag].
+type bagKey struct{}
+
+// Bag is a mutable set of fields, safe for concurrent use. Unlike the fields
+// of [Set] and [Append], which are copied into every derived context, a bag is
+// stored once in a context and updated in place, so that every holder of the
+// context or of its children observes updates. It suits long-lived contexts,
+// such as those of connections or sessions, whose fields change over time.
+//
+// Fields of a bag are not part of [GetAll] or [Logger]; read them with
+// [Bag.Fields], or register [BagFields] as an extractor to have them resolved
+// by [ResolveAll].
+type Bag struct {
+	mu     sync.RWMutex
+	fields []zap.Field
+}
+
+// WithBag returns ctx holding a bag, along with the bag. The bag already held
+// by ctx, if any, is reused.
+func WithBag(ctx context.Context) (context.Context, *Bag) {
+	if bag := BagFrom(ctx); bag != nil {
+		return ctx, bag
+	}
+	bag := &Bag{}
+	return context.WithValue(ctx, bagKey{}, bag), bag
+}
+
+// BagFrom returns the bag held by ctx, or nil. All the methods of a nil bag
+// are safe to call and ignore updates.
+func BagFrom(ctx context.Context) *Bag {
+	bag, _ := ctx.Value(bagKey{}).(*Bag)
+	return bag
+}
+
+// BagFields returns the fields of the bag held by ctx. It is an [Extractor]:
+//
+//	zax.RegisterExtractor(zax.BagFields)
+func BagFields(ctx context.Context) []zap.Field {
+	return BagFrom(ctx).Fields()
+}
+
+// Set updates the fields of b with the same key as one of fields in place,
+// and adds the others, newest first.
+func (b *Bag) Set(fields ...zap.Field) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	var added []zap.Field
+	for _, field := range fields {
+		if i := b.index(field.Key); i >= 0 {
+			b.fields[i] = field
+			continue
+		}
+		added = append(added, field)
+	}
+	if len(added) > 0 {
+		b.fields = concatFields(added, b.fields)
+	}
+}
+
+// Delete removes the fields with keys from b.
+func (b *Bag) Delete(keys ...string) {
+	if b == nil {
+		return
+	}
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	kept := make([]zap.Field, 0, len(b.fields))
+	for _, field := range b.fields {
+		deleted := false
+		for _, key := range keys {
+			if field.Key == key {
+				deleted = true
+				break
+			}
+		}
+		if !deleted {
+			kept = append(kept, field)
+		}
+	}
+	b.fields = kept
+}
+
+// Get returns the field of b with key, if any.
+func (b *Bag) Get(key string) (zap.Field, bool) {
+	if b == nil {
+		return zap.Field{}, false
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	if i := b.index(key); i >= 0 {
+		return b.fields[i], true
+	}
+	return zap.Field{}, false
+}
+
+// Fields returns a copy of the fields of b, newest first.
+func (b *Bag) Fields() []zap.Field {
+	if b == nil {
+		return nil
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return concatFields(b.fields, nil)
+}
+
+// Len returns the number of fields of b.
+func (b *Bag) Len() int {
+	if b == nil {
+		return 0
+	}
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	return len(b.fields)
+}
+
+// index returns the index of the field with key, or -1. b.mu must be held.
+func (b *Bag) index(key string) int {
+	for i, field := range b.fields {
+		if field.Key == key {
+			return i
+		}
+	}
+	return -1
+}
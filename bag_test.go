@@ -0,0 +1,76 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestBag(t *testing.T) {
+	ctx, bag := WithBag(context.Background())
+	child := Append(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	bag.Set(zap.String("user_id", "anonymous"), zap.Int("messages", 0))
+	BagFrom(child).Set(zap.String("user_id", "u-1"), zap.String("room", "lobby"))
+
+	assert.Equal(t, []zap.Field{
+		zap.String("room", "lobby"),
+		zap.String("user_id", "u-1"),
+		zap.Int("messages", 0),
+	}, BagFrom(ctx).Fields(), "updates through any holder are observed by all")
+
+	field, ok := bag.Get("user_id")
+	assert.True(t, ok)
+	assert.Equal(t, zap.String("user_id", "u-1"), field)
+
+	bag.Delete("room", "missing")
+	assert.Equal(t, 2, bag.Len())
+	_, ok = bag.Get("room")
+	assert.False(t, ok)
+
+	again, reused := WithBag(child)
+	assert.Equal(t, child, again)
+	assert.Same(t, bag, reused)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(child), "bag fields are not stored fields")
+}
+
+func TestBagNil(t *testing.T) {
+	bag := BagFrom(context.Background())
+	assert.Nil(t, bag)
+	bag.Set(zap.String("user_id", "u-1"))
+	bag.Delete("user_id")
+	_, ok := bag.Get("user_id")
+	assert.False(t, ok)
+	assert.Nil(t, bag.Fields())
+	assert.Zero(t, bag.Len())
+}
+
+func TestBagFields(t *testing.T) {
+	store := NewStore(WithExtractor(BagFields))
+	ctx, bag := WithBag(store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	bag.Set(zap.String(traceIDKey, "shadowed"), zap.String("session", "s-1"))
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("session", "s-1")}, store.ResolveAll(ctx))
+}
+
+func TestBagConcurrent(t *testing.T) {
+	_, bag := WithBag(context.Background())
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				bag.Set(zap.Int("counter", j), zap.Int("worker", i))
+				bag.Fields()
+			}
+		}(i)
+	}
+	wg.Wait()
+	assert.Equal(t, 2, bag.Len())
+}
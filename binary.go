@@ -0,0 +1,266 @@
+package zax
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// binaryVersion is the first byte of every binary encoding, leaving room for
+// incompatible changes to the format.
+const binaryVersion byte = 1
+
+// Value tags of the binary encoding.
+const (
+	binaryString     byte = 's'
+	binaryBool       byte = 'b'
+	binaryInt        byte = 'i'
+	binaryUint       byte = 'u'
+	binaryFloat      byte = 'f'
+	binaryDuration   byte = 'd'
+	binaryTime       byte = 't'
+	binaryBytes      byte = 'y'
+	binaryByteString byte = 'w'
+	binaryError      byte = 'e'
+	binaryJSON       byte = 'j'
+)
+
+var errBinaryTruncated = errors.New("zax: truncated binary fields")
+
+// MarshalBinary encodes the fields stored in ctx in a compact binary format,
+// meant for size-constrained carriers such as Kafka headers or SQS message
+// attributes. See [EncodeHeader] for a text-safe variant.
+func MarshalBinary(ctx context.Context) ([]byte, error) {
+	return defaultStore.EncodeBinary(ctx)
+}
+
+// EncodeBinary encodes the fields of the store in ctx; see [MarshalBinary].
+func (s *Store) EncodeBinary(ctx context.Context) ([]byte, error) {
+	b := []byte{binaryVersion}
+	for _, field := range s.rawFields(ctx) {
+		var err error
+		if b, err = appendBinaryField(b, field); err != nil {
+			return nil, err
+		}
+	}
+	return b, nil
+}
+
+// UnmarshalBinary appends the fields encoded by [MarshalBinary] to ctx.
+func UnmarshalBinary(ctx context.Context, data []byte) (context.Context, error) {
+	return defaultStore.DecodeBinary(ctx, data)
+}
+
+// DecodeBinary appends the fields encoded by [Store.EncodeBinary] to the
+// store in ctx; see [UnmarshalBinary].
+func (s *Store) DecodeBinary(ctx context.Context, data []byte) (context.Context, error) {
+	if len(data) == 0 || data[0] != binaryVersion {
+		return ctx, errors.New("zax: unsupported binary fields version")
+	}
+	var fields []zap.Field
+	for data = data[1:]; len(data) > 0; {
+		field, n, err := consumeBinaryField(data)
+		if err != nil {
+			return ctx, err
+		}
+		fields = append(fields, field)
+		data = data[n:]
+	}
+	if len(fields) == 0 {
+		return ctx, nil
+	}
+	return s.Append(ctx, fields), nil
+}
+
+// EncodeHeader returns the binary encoding of the fields stored in ctx framed
+// as unpadded URL-safe base64. If budget is positive, the oldest fields are
+// left out until the result is at most budget bytes long.
+func EncodeHeader(ctx context.Context, budget int) (string, error) {
+	return defaultStore.EncodeHeader(ctx, budget)
+}
+
+// EncodeHeader encodes the fields of the store in ctx as a header value; see
+// [EncodeHeader].
+func (s *Store) EncodeHeader(ctx context.Context, budget int) (string, error) {
+	b := []byte{binaryVersion}
+	for _, field := range s.rawFields(ctx) {
+		next, err := appendBinaryField(b, field)
+		if err != nil {
+			return "", err
+		}
+		if budget > 0 && base64.RawURLEncoding.EncodedLen(len(next)) > budget {
+			break
+		}
+		b = next
+	}
+	if len(b) == 1 {
+		return "", nil
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// DecodeHeader appends the fields encoded by [EncodeHeader] to ctx.
+func DecodeHeader(ctx context.Context, header string) (context.Context, error) {
+	return defaultStore.DecodeHeader(ctx, header)
+}
+
+// DecodeHeader appends the fields encoded by [Store.EncodeHeader] to the
+// store in ctx; see [DecodeHeader].
+func (s *Store) DecodeHeader(ctx context.Context, header string) (context.Context, error) {
+	if header == "" {
+		return ctx, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(header)
+	if err != nil {
+		return ctx, fmt.Errorf("zax: decode header: %w", err)
+	}
+	return s.DecodeBinary(ctx, data)
+}
+
+func appendBinaryField(b []byte, field zap.Field) ([]byte, error) {
+	if field.Type == zapcore.SkipType {
+		return b, nil
+	}
+	b = appendBinaryString(b, field.Key)
+	switch field.Type {
+	case zapcore.StringType:
+		return appendBinaryString(append(b, binaryString), field.String), nil
+	case zapcore.StringerType:
+		return appendBinaryString(append(b, binaryString), field.Interface.(fmt.Stringer).String()), nil
+	case zapcore.BinaryType:
+		return appendBinaryString(append(b, binaryBytes), string(field.Interface.([]byte))), nil
+	case zapcore.ByteStringType:
+		return appendBinaryString(append(b, binaryByteString), string(field.Interface.([]byte))), nil
+	case zapcore.ErrorType:
+		return appendBinaryString(append(b, binaryError), field.Interface.(error).Error()), nil
+	case zapcore.BoolType:
+		return append(b, binaryBool, byte(field.Integer)), nil
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		return binary.AppendVarint(append(b, binaryInt), field.Integer), nil
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType:
+		return binary.AppendUvarint(append(b, binaryUint), uint64(field.Integer)), nil
+	case zapcore.DurationType:
+		return binary.AppendVarint(append(b, binaryDuration), field.Integer), nil
+	}
+	return appendBinaryOther(b, field)
+}
+
+func appendBinaryOther(b []byte, field zap.Field) ([]byte, error) {
+	switch field.Type {
+	case zapcore.Float64Type:
+		return binary.LittleEndian.AppendUint64(append(b, binaryFloat), uint64(field.Integer)), nil
+	case zapcore.Float32Type:
+		f := float64(math.Float32frombits(uint32(field.Integer)))
+		return binary.LittleEndian.AppendUint64(append(b, binaryFloat), math.Float64bits(f)), nil
+	case zapcore.TimeType:
+		return binary.AppendVarint(append(b, binaryTime), field.Integer), nil
+	case zapcore.TimeFullType:
+		return binary.AppendVarint(append(b, binaryTime), field.Interface.(time.Time).UnixNano()), nil
+	}
+	encoded, err := json.Marshal(EncodedValue(field))
+	if err != nil {
+		return nil, fmt.Errorf("zax: marshal field %q: %w", field.Key, err)
+	}
+	return appendBinaryString(append(b, binaryJSON), string(encoded)), nil
+}
+
+func appendBinaryString(b []byte, s string) []byte {
+	return append(binary.AppendUvarint(b, uint64(len(s))), s...)
+}
+
+// consumeBinaryField decodes the field at the start of data, returning it
+// along with the number of bytes it took.
+func consumeBinaryField(data []byte) (zap.Field, int, error) {
+	key, n, err := consumeBinaryString(data)
+	if err != nil || n >= len(data) {
+		return zap.Field{}, 0, errBinaryTruncated
+	}
+	tag := data[n]
+	n++
+	field, m, err := consumeBinaryValue(tag, data[n:])
+	if err != nil {
+		return zap.Field{}, 0, err
+	}
+	field.Key = key
+	return field, n + m, nil
+}
+
+func consumeBinaryValue(tag byte, data []byte) (zap.Field, int, error) {
+	switch tag {
+	case binaryString, binaryBytes, binaryByteString, binaryError, binaryJSON:
+		s, n, err := consumeBinaryString(data)
+		if err != nil {
+			return zap.Field{}, 0, err
+		}
+		field, err := binaryStringField(tag, s)
+		return field, n, err
+	case binaryBool:
+		if len(data) < 1 {
+			return zap.Field{}, 0, errBinaryTruncated
+		}
+		return zap.Bool("", data[0] == 1), 1, nil
+	case binaryFloat:
+		if len(data) < 8 {
+			return zap.Field{}, 0, errBinaryTruncated
+		}
+		return zap.Float64("", math.Float64frombits(binary.LittleEndian.Uint64(data))), 8, nil
+	case binaryUint:
+		v, n := binary.Uvarint(data)
+		if n <= 0 {
+			return zap.Field{}, 0, errBinaryTruncated
+		}
+		return zap.Uint64("", v), n, nil
+	}
+	return consumeBinaryVarint(tag, data)
+}
+
+func consumeBinaryVarint(tag byte, data []byte) (zap.Field, int, error) {
+	v, n := binary.Varint(data)
+	if n <= 0 {
+		return zap.Field{}, 0, errBinaryTruncated
+	}
+	switch tag {
+	case binaryInt:
+		return zap.Int64("", v), n, nil
+	case binaryDuration:
+		return zap.Duration("", time.Duration(v)), n, nil
+	case binaryTime:
+		return zap.Time("", time.Unix(0, v)), n, nil
+	}
+	return zap.Field{}, 0, fmt.Errorf("zax: unknown binary field tag %q", tag)
+}
+
+func binaryStringField(tag byte, s string) (zap.Field, error) {
+	switch tag {
+	case binaryBytes:
+		return zap.Binary("", []byte(s)), nil
+	case binaryByteString:
+		return zap.ByteString("", []byte(s)), nil
+	case binaryError:
+		return zap.Error(errors.New(s)), nil
+	case binaryJSON:
+		var value any
+		if err := json.Unmarshal([]byte(s), &value); err != nil {
+			return zap.Field{}, fmt.Errorf("zax: unmarshal json value: %w", err)
+		}
+		return zap.Any("", value), nil
+	}
+	return zap.String("", s), nil
+}
+
+func consumeBinaryString(data []byte) (string, int, error) {
+	length, n := binary.Uvarint(data)
+	if n <= 0 || uint64(len(data)-n) < length {
+		return "", 0, errBinaryTruncated
+	}
+	end := n + int(length)
+	return string(data[n:end]), end, nil
+}
@@ -0,0 +1,109 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestBinaryRoundTrip(t *testing.T) {
+	now := time.Now()
+	ctx := Set(context.Background(), []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Bool("sampled", true),
+		zap.Int("attempt", -3),
+		zap.Uint("shard", 7),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("budget", time.Second),
+		zap.Time("start", now),
+		zap.Binary("payload", []byte{1, 2, 3}),
+		zap.ByteString("body", []byte("hi")),
+		zap.NamedError("cause", errors.New("boom")),
+		zap.Strings("tags", []string{"a", "b"}),
+	})
+
+	data, err := MarshalBinary(ctx)
+	require.NoError(t, err)
+	restored, err := UnmarshalBinary(context.Background(), data)
+	require.NoError(t, err)
+
+	got := GetAll(restored)
+	require.Len(t, got, 11)
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Bool("sampled", true),
+		zap.Int64("attempt", -3),
+		zap.Uint64("shard", 7),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("budget", time.Second),
+		zap.Time("start", time.Unix(0, now.UnixNano())),
+		zap.Binary("payload", []byte{1, 2, 3}),
+		zap.ByteString("body", []byte("hi")),
+	}, got[:9])
+	assert.EqualError(t, got[9].Interface.(error), "boom")
+	assert.Equal(t, zap.Any("tags", []any{"a", "b"}), got[10])
+}
+
+func TestStoreBinary(t *testing.T) {
+	store := NewStore(WithDefaults(zap.String("service", "api")))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	data, err := store.EncodeBinary(ctx)
+	require.NoError(t, err)
+	restored, err := store.DecodeBinary(context.Background(), data)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.StoredView(restored).Unsafe())
+
+	header, err := store.EncodeHeader(ctx, 0)
+	require.NoError(t, err)
+	restored, err = store.DecodeHeader(context.Background(), header)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.StoredView(restored).Unsafe())
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := UnmarshalBinary(ctx, nil)
+	assert.Error(t, err)
+
+	data, err := MarshalBinary(Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	require.NoError(t, err)
+	_, err = UnmarshalBinary(ctx, data[:len(data)-1])
+	assert.ErrorIs(t, err, errBinaryTruncated)
+}
+
+func TestHeaderBudget(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String("old", "value")})
+	ctx = Append(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	full, err := EncodeHeader(ctx, 0)
+	require.NoError(t, err)
+	restored, err := DecodeHeader(context.Background(), full)
+	require.NoError(t, err)
+	assert.Len(t, GetAll(restored), 2)
+
+	limited, err := EncodeHeader(ctx, len(full)-1)
+	require.NoError(t, err)
+	assert.Less(t, len(limited), len(full))
+	restored, err = DecodeHeader(context.Background(), limited)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(restored))
+
+	empty, err := EncodeHeader(ctx, 1)
+	require.NoError(t, err)
+	assert.Empty(t, empty)
+	restored, err = DecodeHeader(ctx, empty)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, restored)
+
+	_, err = DecodeHeader(ctx, "!!")
+	assert.Error(t, err)
+}
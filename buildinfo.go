@@ -0,0 +1,60 @@
+package zax
+
+import (
+	"runtime/debug"
+
+	"go.uber.org/zap"
+)
+
+// Keys of the fields returned by [BuildInfoFields].
+const (
+	VersionKey   = "version"
+	RevisionKey  = "vcs_revision"
+	ModifiedKey  = "vcs_modified"
+	GoVersionKey = "go_version"
+)
+
+// Values of the build information.
+const (
+	develVersion   = "(devel)"
+	vcsRevisionKey = "vcs.revision"
+	vcsModifiedKey = "vcs.modified"
+)
+
+// readBuildInfo is swapped by tests.
+var readBuildInfo = debug.ReadBuildInfo
+
+// BuildInfoFields returns fields identifying the build of the running binary,
+// so that logs are attributable to an exact build: the main module version,
+// the VCS revision and whether the working tree had local modifications, as
+// far as they were recorded, and the Go version. It returns nil if the binary
+// lacks build information.
+func BuildInfoFields() []zap.Field {
+	info, ok := readBuildInfo()
+	if !ok {
+		return nil
+	}
+	var fields []zap.Field
+	if version := info.Main.Version; version != "" && version != develVersion {
+		fields = append(fields, zap.String(VersionKey, version))
+	}
+	for _, setting := range info.Settings {
+		switch setting.Key {
+		case vcsRevisionKey:
+			fields = append(fields, zap.String(RevisionKey, setting.Value))
+		case vcsModifiedKey:
+			fields = append(fields, zap.Bool(ModifiedKey, setting.Value == "true"))
+		}
+	}
+	return append(fields, zap.String(GoVersionKey, info.GoVersion))
+}
+
+// WithBuildInfo adds the [BuildInfoFields] to the default fields; see
+// [SetDefaults]. They are kept apart from the fields set by [WithDefaults],
+// which take precedence, so that setting defaults doesn't drop them.
+func WithBuildInfo() Option {
+	fields := BuildInfoFields()
+	return func(c *config) {
+		c.buildInfo = fields
+	}
+}
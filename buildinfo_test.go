@@ -0,0 +1,50 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"runtime/debug"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestBuildInfoFields(t *testing.T) {
+	t.Cleanup(func() { readBuildInfo = debug.ReadBuildInfo })
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{
+			GoVersion: "go1.23.0",
+			Main:      debug.Module{Path: "example.com/api", Version: "v1.2.3"},
+			Settings: []debug.BuildSetting{
+				{Key: "vcs", Value: "git"},
+				{Key: "vcs.revision", Value: "0123abc"},
+				{Key: "vcs.modified", Value: "true"},
+			},
+		}, true
+	}
+
+	expected := []zap.Field{
+		zap.String(VersionKey, "v1.2.3"),
+		zap.String(RevisionKey, "0123abc"),
+		zap.Bool(ModifiedKey, true),
+		zap.String(GoVersionKey, "go1.23.0"),
+	}
+	assert.Equal(t, expected, BuildInfoFields())
+
+	store := NewStore(WithBuildInfo(), WithDefaults(zap.String("service", "api")))
+	assert.Equal(t, append([]zap.Field{zap.String("service", "api")}, expected...), store.GetAll(context.Background()),
+		"setting defaults keeps the build information")
+	store.Configure(WithDefaults(zap.String(VersionKey, "v2")))
+	assert.Equal(t, append([]zap.Field{zap.String(VersionKey, "v2")}, expected[1:]...), store.GetAll(context.Background()),
+		"defaults override the build information")
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) {
+		return &debug.BuildInfo{GoVersion: "go1.23.0", Main: debug.Module{Version: "(devel)"}}, true
+	}
+	assert.Equal(t, []zap.Field{zap.String(GoVersionKey, "go1.23.0")}, BuildInfoFields())
+
+	readBuildInfo = func() (*debug.BuildInfo, bool) { return nil, false }
+	assert.Nil(t, BuildInfoFields())
+}
@@ -0,0 +1,74 @@
+package zax
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+
+	"go.uber.org/zap"
+)
+
+// BytesEncoding is the text encoding of the binary values stored by
+// [SetBytes].
+type BytesEncoding int
+
+const (
+	// BytesBase64 encodes values as standard padded base64.
+	BytesBase64 BytesEncoding = iota
+	// BytesHex encodes values as lower case hexadecimal.
+	BytesHex
+)
+
+// HashedPrefix starts the values stored by [SetBytes] in place of binary
+// values longer than the configured threshold, followed by their SHA-256
+// digest, encoded as configured.
+const HashedPrefix = "sha256:"
+
+// WithBytesEncoding sets how [SetBytes] encodes binary values, and the
+// length, in bytes, above which they are replaced by their hash, prefixed by
+// [HashedPrefix]; 0 never hashes. The default is base64, never hashed.
+func WithBytesEncoding(encoding BytesEncoding, hashAbove int) Option {
+	return func(c *config) {
+		c.bytesEncoding = encoding
+		c.bytesHashAbove = hashAbove
+	}
+}
+
+// SetBytes returns ctx with b appended, like [Append], as a string field with
+// key, encoded as configured with [WithBytesEncoding]. Raw [zap.Binary]
+// values are encoded differently by each encoder, and often break the
+// parsers of log pipelines.
+func SetBytes(ctx context.Context, key string, b []byte) context.Context {
+	return defaultStore.SetBytes(ctx, key, b)
+}
+
+// SetBytes returns ctx with b appended to the fields of s; see [SetBytes].
+func (s *Store) SetBytes(ctx context.Context, key string, b []byte) context.Context {
+	return s.Append(ctx, []zap.Field{s.BytesField(key, b)})
+}
+
+// BytesField returns a string field with key holding b, encoded as by
+// [SetBytes], to be stored along with other fields.
+func BytesField(key string, b []byte) zap.Field {
+	return defaultStore.BytesField(key, b)
+}
+
+// BytesField returns b encoded as configured for s; see [BytesField].
+func (s *Store) BytesField(key string, b []byte) zap.Field {
+	return zap.String(key, s.loadConfig().encodeBytes(b))
+}
+
+// encodeBytes returns b, or its hash if it is too long, encoded as
+// configured.
+func (c *config) encodeBytes(b []byte) string {
+	prefix := ""
+	if c.bytesHashAbove > 0 && len(b) > c.bytesHashAbove {
+		sum := sha256.Sum256(b)
+		prefix, b = HashedPrefix, sum[:]
+	}
+	if c.bytesEncoding == BytesHex {
+		return prefix + hex.EncodeToString(b)
+	}
+	return prefix + base64.StdEncoding.EncodeToString(b)
+}
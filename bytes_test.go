@@ -0,0 +1,55 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSetBytes(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	ctx = SetBytes(ctx, "payload", []byte{0xff, 0x00, 'a'})
+
+	field, ok := GetField(ctx, "payload")
+	require.True(t, ok)
+	assert.Equal(t, zap.String("payload", "/wBh"), field)
+	_, ok = GetField(ctx, traceIDKey)
+	assert.True(t, ok, "other fields are kept")
+}
+
+func TestWithBytesEncoding(t *testing.T) {
+	large := []byte("more than eight bytes")
+	sum := sha256.Sum256(large)
+	tests := map[string]struct {
+		encoding  BytesEncoding
+		hashAbove int
+		value     []byte
+		expected  string
+	}{
+		"hex":              {encoding: BytesHex, value: []byte{0xff, 0x00}, expected: "ff00"},
+		"small not hashed": {encoding: BytesHex, hashAbove: 8, value: []byte{0xff}, expected: "ff"},
+		"large hashed":     {encoding: BytesHex, hashAbove: 8, value: large, expected: HashedPrefix + hex.EncodeToString(sum[:])},
+		"base64 hashed":    {hashAbove: 8, value: large, expected: HashedPrefix + base64.StdEncoding.EncodeToString(sum[:])},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			store := NewStore(WithBytesEncoding(tc.encoding, tc.hashAbove))
+
+			ctx := store.SetBytes(context.Background(), "payload", tc.value)
+
+			field, ok := store.GetField(ctx, "payload")
+			require.True(t, ok)
+			assert.Equal(t, tc.expected, field.String)
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package zax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// CarrierPrefix is prepended to field keys written to a [Carrier], so that
+// Extract can tell zax fields apart from the carrier's other entries.
+const CarrierPrefix = "zax-"
+
+// Carrier is a transport-agnostic storage for string key/value pairs, such
+// as HTTP headers, gRPC metadata or message attributes. Its method set
+// matches OpenTelemetry's propagation.TextMapCarrier, so existing carrier
+// implementations can be reused as is.
+type Carrier interface {
+	// Get returns the value associated with key.
+	Get(key string) string
+	// Set stores the key/value pair.
+	Set(key string, value string)
+	// Keys lists the keys stored in the carrier.
+	Keys() []string
+}
+
+// Inject writes the fields stored in ctx to carrier, one entry per field.
+// Values are written in their string representation; use [MarshalJSON] when
+// field types must survive the trip.
+func Inject(ctx context.Context, carrier Carrier) {
+	defaultStore.Inject(ctx, carrier)
+}
+
+// Inject writes the fields of the store in ctx to carrier; see [Inject].
+func (s *Store) Inject(ctx context.Context, carrier Carrier) {
+	cfg := s.loadConfig()
+	fields := s.rawFields(ctx)
+	// Fields are stored newest first; write the oldest first so that newer
+	// values win in carriers that overwrite on Set.
+	for i := len(fields) - 1; i >= 0; i-- {
+		if fields[i].Type == zapcore.SkipType || !cfg.propagates(fields[i].Key) {
+			continue
+		}
+		carrier.Set(CarrierPrefix+fields[i].Key, textValue(fields[i]))
+	}
+}
+
+// Extract appends the fields written by [Inject] to ctx, as string fields.
+func Extract(ctx context.Context, carrier Carrier) context.Context {
+	return defaultStore.Extract(ctx, carrier)
+}
+
+// Extract appends the fields written by [Store.Inject] to the store in ctx;
+// see [Extract].
+func (s *Store) Extract(ctx context.Context, carrier Carrier) context.Context {
+	cfg := s.loadConfig()
+	// Carriers list their keys in no particular order, such as map order;
+	// sort them so that the fields are stored in the same order every time.
+	keys := append([]string(nil), carrier.Keys()...)
+	sort.Strings(keys)
+	var fields []zap.Field
+	for _, key := range keys {
+		if len(key) <= len(CarrierPrefix) || !strings.EqualFold(key[:len(CarrierPrefix)], CarrierPrefix) {
+			continue
+		}
+		if !cfg.propagates(key[len(CarrierPrefix):]) {
+			continue
+		}
+		fields = append(fields, zap.String(key[len(CarrierPrefix):], carrier.Get(key)))
+	}
+	if len(fields) == 0 {
+		return ctx
+	}
+	return s.Append(ctx, fields)
+}
+
+// MapCarrier is a [Carrier] backed by a map.
+type MapCarrier map[string]string
+
+// Get returns the value associated with key.
+func (c MapCarrier) Get(key string) string {
+	return c[key]
+}
+
+// Set stores the key/value pair.
+func (c MapCarrier) Set(key string, value string) {
+	c[key] = value
+}
+
+// Keys lists the keys stored in the carrier.
+func (c MapCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// HeaderCarrier is a [Carrier] backed by HTTP headers. Header names are case
+// insensitive, so field keys extracted from it are lower case.
+type HeaderCarrier http.Header
+
+// Get returns the value associated with key.
+func (c HeaderCarrier) Get(key string) string {
+	return http.Header(c).Get(key)
+}
+
+// Set stores the key/value pair.
+func (c HeaderCarrier) Set(key string, value string) {
+	http.Header(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier, in lower case.
+func (c HeaderCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, strings.ToLower(key))
+	}
+	return keys
+}
+
+// textValue renders the value of field as a string.
+func textValue(field zap.Field) string {
+	if field.Type == zapcore.StringType {
+		return field.String
+	}
+	codec, ok := jsonCodecs[field.Type]
+	if !ok {
+		codec = anyCodec
+	}
+	switch value := codec.encode(field).(type) {
+	case string:
+		return value
+	case time.Time:
+		return value.Format(time.RFC3339Nano)
+	case []any, map[string]any, []byte:
+		encoded, _ := json.Marshal(value)
+		return string(encoded)
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,89 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestInjectExtract(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Int("attempt", 3),
+		zap.Duration("budget", time.Second),
+		zap.Strings("tags", []string{"a", "b"}),
+	})
+
+	tests := map[string]struct {
+		carrier Carrier
+	}{
+		"map carrier":    {carrier: MapCarrier{"unrelated": "value"}},
+		"header carrier": {carrier: HeaderCarrier(http.Header{"Unrelated": []string{"value"}})},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			Inject(ctx, tc.carrier)
+			assert.Equal(t, testTraceID, tc.carrier.Get(CarrierPrefix+traceIDKey))
+
+			extracted := Extract(context.Background(), tc.carrier)
+			assert.Len(t, GetAll(extracted), 4)
+			for key, value := range map[string]string{
+				traceIDKey: testTraceID,
+				"attempt":  "3",
+				"budget":   "1s",
+				"tags":     `["a","b"]`,
+			} {
+				field, ok := GetField(extracted, key)
+				assert.True(t, ok, key)
+				assert.Equal(t, value, field.String, key)
+			}
+		})
+	}
+}
+
+func TestInjectNewestWins(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, "old")})
+	ctx = Append(ctx, []zap.Field{zap.String(traceIDKey, "new")})
+
+	carrier := MapCarrier{}
+	Inject(ctx, carrier)
+	assert.Equal(t, "new", carrier.Get(CarrierPrefix+traceIDKey))
+}
+
+func TestExtractWithoutFields(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, Extract(ctx, MapCarrier{"zax-": "empty key"}))
+}
+
+func TestExtractSortsKeys(t *testing.T) {
+	carrier := MapCarrier{}
+	for _, key := range []string{"d", "b", "e", "a", "c"} {
+		carrier.Set(CarrierPrefix+key, key)
+	}
+
+	expected := GetAll(Extract(context.Background(), carrier))
+	for i := 0; i < 10; i++ {
+		assert.Equal(t, expected, GetAll(Extract(context.Background(), carrier)))
+	}
+}
+
+func TestPropagatedKeysWithKeyMapper(t *testing.T) {
+	store := NewStore(WithKeyMapper(PrefixKeys("app.")), WithPropagatedKeys(traceIDKey))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("internal", "x")})
+
+	carrier := MapCarrier{}
+	store.Inject(ctx, carrier)
+	assert.Equal(t, MapCarrier{CarrierPrefix + traceIDKey: testTraceID}, carrier)
+
+	extracted := store.Extract(context.Background(), carrier)
+	field, ok := store.GetField(extracted, traceIDKey)
+	assert.True(t, ok)
+	assert.Equal(t, zap.String("app."+traceIDKey, testTraceID), field)
+}
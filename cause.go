@@ -0,0 +1,52 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// LogCause logs why ctx is done, if it is, and reports whether it did. The
+// entry carries [context.Cause], the deadline of ctx, if any, and the fields
+// stored in ctx, making it diagnosable who canceled a request and why.
+func LogCause(ctx context.Context, logger *zap.Logger) bool {
+	if ctx.Err() == nil {
+		return false
+	}
+	Logger(ctx, logger).WithOptions(zap.AddCallerSkip(1)).Warn("context done", doneFields(ctx)...)
+	return true
+}
+
+// WatchCause logs why ctx is done through logger as soon as it is; see
+// [LogCause]. Calling the returned stop function stops watching, and reports
+// whether it did so before ctx was done.
+func WatchCause(ctx context.Context, logger *zap.Logger) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		LogCause(ctx, logger)
+	})
+}
+
+// LogOnDone logs msg at info level through logger once ctx is done, with the
+// same fields as [LogCause]. It is a cheap way to get a completion record per
+// request:
+//
+//	ctx, cancel := context.WithCancel(r.Context())
+//	defer cancel()
+//	zax.LogOnDone(ctx, logger, "request completed")
+//
+// Calling the returned stop function cancels the entry, and reports whether
+// it did so before ctx was done.
+func LogOnDone(ctx context.Context, logger *zap.Logger, msg string) (stop func() bool) {
+	return context.AfterFunc(ctx, func() {
+		Logger(ctx, logger).Info(msg, doneFields(ctx)...)
+	})
+}
+
+// doneFields returns the cause and deadline of ctx.
+func doneFields(ctx context.Context) []zap.Field {
+	fields := []zap.Field{zap.NamedError("cause", context.Cause(ctx))}
+	if deadline, ok := ctx.Deadline(); ok {
+		fields = append(fields, zap.Time("deadline", deadline))
+	}
+	return fields
+}
@@ -0,0 +1,69 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestLogCause(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	deadline := time.Now().Add(time.Hour)
+	ctx, cancelDeadline := context.WithDeadline(ctx, deadline)
+	defer cancelDeadline()
+	ctx, cancel := context.WithCancelCause(ctx)
+
+	assert.False(t, LogCause(ctx, logs.GetZapLogger()))
+	cancel(errors.New("client went away"))
+	assert.True(t, LogCause(ctx, logs.GetZapLogger()))
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "context done", entries[0].Message)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "client went away", fields["cause"])
+	assert.Equal(t, testTraceID, fields[traceIDKey])
+	assert.WithinDuration(t, deadline, fields["deadline"].(time.Time), 0)
+}
+
+func TestWatchCause(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	WatchCause(ctx, logs.GetZapLogger())
+	stopped, stopCancel := context.WithCancel(context.Background())
+	assert.True(t, WatchCause(stopped, logs.GetZapLogger())())
+
+	cancel()
+	stopCancel()
+	assert.Eventually(t, func() bool { return len(logs.GetRecordedLogs()) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	assert.Len(t, logs.GetRecordedLogs(), 1)
+	assert.Equal(t, "context canceled", logs.GetRecordedLogs()[0].ContextMap()["cause"])
+}
+
+func TestLogOnDone(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx, cancel := context.WithCancelCause(Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	LogOnDone(ctx, logs.GetZapLogger(), "request completed")
+	stopped, stopCancel := context.WithCancel(context.Background())
+	assert.True(t, LogOnDone(stopped, logs.GetZapLogger(), "never logged")())
+
+	cancel(errors.New("handler returned"))
+	stopCancel()
+	assert.Eventually(t, func() bool { return len(logs.GetRecordedLogs()) == 1 }, time.Second, time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, "request completed", entries[0].Message)
+	assert.Equal(t, zap.InfoLevel, entries[0].Level)
+	assert.Equal(t, "handler returned", entries[0].ContextMap()["cause"])
+	assert.Equal(t, testTraceID, entries[0].ContextMap()[traceIDKey])
+}
@@ -0,0 +1,90 @@
+package zax
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// checkThrough returns ce with a core writing ent through the cores that core
+// accepts probe with, if any, with the fields transformed by transform, if
+// not nil. It lets cores adding fields at write time keep the filtering, such
+// as sampling, that the core they wrap does in Check. Probe is ent, unless
+// the level of ent must be lifted to pass the level gate of core.
+func checkThrough(
+	core zapcore.Core,
+	ent, probe zapcore.Entry,
+	ce *zapcore.CheckedEntry,
+	transform func(zapcore.Entry, []zapcore.Field) []zapcore.Field,
+) *zapcore.CheckedEntry {
+	checked := core.Check(probe, nil)
+	if checked == nil {
+		return ce
+	}
+	return ce.AddCore(ent, &checkedCore{Core: core, checked: checked, transform: transform})
+}
+
+// filterThrough is like [checkThrough], but writes ent only if keep accepts
+// its fields. It lets cores deciding on the fields of entries, such as
+// samplers, keep the filtering of the core they wrap.
+func filterThrough(
+	core zapcore.Core,
+	ent zapcore.Entry,
+	ce *zapcore.CheckedEntry,
+	keep func(zapcore.Entry, []zapcore.Field) bool,
+) *zapcore.CheckedEntry {
+	checked := core.Check(ent, nil)
+	if checked == nil {
+		return ce
+	}
+	return ce.AddCore(ent, &checkedCore{Core: core, checked: checked, keep: keep})
+}
+
+// checkedCore writes entries through the cores a wrapped core accepted them
+// with; see [checkThrough] and [filterThrough].
+type checkedCore struct {
+	zapcore.Core
+	checked   *zapcore.CheckedEntry
+	transform func(zapcore.Entry, []zapcore.Field) []zapcore.Field
+	keep      func(zapcore.Entry, []zapcore.Field) bool
+}
+
+func (c *checkedCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if c.keep != nil && !c.keep(ent, fields) {
+		return nil
+	}
+	if c.transform != nil {
+		fields = c.transform(ent, fields)
+	}
+	return writeChecked(c.checked, ent, fields)
+}
+
+// writeChecked writes ent with fields through checked, which is then
+// recycled, returning the errors of its cores.
+func writeChecked(checked *zapcore.CheckedEntry, ent zapcore.Entry, fields []zapcore.Field) error {
+	if checked == nil {
+		return nil
+	}
+	var errs writeErrors
+	checked.Entry, checked.ErrorOutput = ent, &errs
+	checked.Write(fields...)
+	return errs.err()
+}
+
+// writeErrors collects the write errors a checked entry reports to its error
+// output, as "<time> write error: <errors>".
+type writeErrors struct {
+	bytes.Buffer
+}
+
+func (w *writeErrors) Sync() error { return nil }
+
+func (w *writeErrors) err() error {
+	if w.Len() == 0 {
+		return nil
+	}
+	_, msg, _ := strings.Cut(strings.TrimSpace(w.String()), "write error: ")
+	return errors.New(msg)
+}
@@ -0,0 +1,83 @@
+package zax
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// newSampledLogger returns a logger keeping only the first entry with each
+// level and message, recording them in the returned logs.
+func newSampledLogger(level zapcore.Level) (*zap.Logger, *observer.ObservedLogs) {
+	core, logs := observer.New(level)
+	return zap.New(zapcore.NewSamplerWithOptions(core, time.Hour, 1, 0)), logs
+}
+
+// newTeeCore returns a tee of cores recording the entries at info level and
+// above, and at error level and above, in the returned logs.
+func newTeeCore() (zapcore.Core, *observer.ObservedLogs, *observer.ObservedLogs) {
+	info, infoLogs := observer.New(zapcore.InfoLevel)
+	errs, errorLogs := observer.New(zapcore.ErrorLevel)
+	return zapcore.NewTee(info, errs), infoLogs, errorLogs
+}
+
+// failingCore fails every write.
+type failingCore struct {
+	zapcore.Core
+}
+
+func (c failingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return ce.AddCore(ent, c)
+}
+
+func (c failingCore) Write(zapcore.Entry, []zapcore.Field) error {
+	return errors.New("disk full")
+}
+
+func TestCheckThrough(t *testing.T) {
+	logger, logs := newSampledLogger(zapcore.InfoLevel)
+	core := logger.Core()
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "sampled"}
+	add := func(_ zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+		return append(fields, zap.Bool("added", true))
+	}
+
+	for i := 0; i < 3; i++ {
+		if ce := checkThrough(core, ent, ent, nil, add); ce != nil {
+			ce.Write()
+		}
+	}
+	debug := zapcore.Entry{Level: zapcore.DebugLevel, Message: "debug"}
+	assert.Nil(t, checkThrough(core, debug, debug, nil, add))
+
+	if assert.Equal(t, 1, logs.Len()) {
+		assert.Equal(t, true, logs.All()[0].ContextMap()["added"])
+	}
+}
+
+func TestFilterThrough(t *testing.T) {
+	core, infoLogs, errorLogs := newTeeCore()
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "filtered"}
+	keep := func(_ zapcore.Entry, fields []zapcore.Field) bool { return len(fields) > 0 }
+
+	filterThrough(core, ent, nil, keep).Write()
+	filterThrough(core, ent, nil, keep).Write(zap.Bool("kept", true))
+	debug := zapcore.Entry{Level: zapcore.DebugLevel, Message: "debug"}
+	assert.Nil(t, filterThrough(core, debug, nil, keep))
+
+	assert.Equal(t, 1, infoLogs.Len())
+	assert.Zero(t, errorLogs.Len())
+}
+
+func TestWriteCheckedError(t *testing.T) {
+	ent := zapcore.Entry{Level: zapcore.InfoLevel, Message: "failed"}
+	checked := failingCore{zapcore.NewNopCore()}.Check(ent, nil)
+
+	assert.EqualError(t, writeChecked(checked, ent, nil), "disk full")
+	assert.NoError(t, writeChecked(nil, ent, nil))
+}
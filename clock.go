@@ -0,0 +1,36 @@
+package zax
+
+import "go.uber.org/zap/zapcore"
+
+// WithClock sets the clock time-based features read the time and create
+// tickers with; the default is [zapcore.DefaultClock]. They are [Heartbeat],
+// the reports of throttlers on Sync, and, for the default store, the run
+// durations of zaxcron and the request timings of zaxhttp. Tests pass a
+// manual clock, such as the one of zaxtest, to make these features
+// deterministic, along with [zap.WithClock] for the time of entries, which
+// the deadline fields, rate limiters and throttlers use. A nil clock
+// restores the default.
+func WithClock(clock zapcore.Clock) Option {
+	return func(c *config) {
+		c.clock = clock
+	}
+}
+
+// Clock returns the clock of the default store, for packages extending zax
+// with time-based features; see [WithClock].
+func Clock() zapcore.Clock {
+	return defaultStore.Clock()
+}
+
+// Clock returns the clock of s; see [Clock].
+func (s *Store) Clock() zapcore.Clock {
+	return s.loadConfig().now()
+}
+
+// now returns the configured clock.
+func (c *config) now() zapcore.Clock {
+	if c.clock == nil {
+		return zapcore.DefaultClock
+	}
+	return c.clock
+}
@@ -0,0 +1,28 @@
+package zax_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+)
+
+func TestWithClock(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	clock := zaxtest.NewClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	store := zax.NewStore(zax.WithClock(clock))
+
+	stop := store.Heartbeat(context.Background(), logs.GetZapLogger(), time.Minute, "still working")
+	defer stop()
+	clock.Add(time.Minute)
+	assert.Eventually(t, func() bool { return len(logs.GetRecordedLogs()) == 1 }, time.Second, time.Millisecond)
+	clock.Add(time.Minute)
+	assert.Eventually(t, func() bool { return len(logs.GetRecordedLogs()) == 2 }, time.Second, time.Millisecond)
+
+	entries := logs.GetRecordedLogs()
+	assert.Equal(t, time.Minute, entries[0].ContextMap()["elapsed"])
+	assert.Equal(t, 2*time.Minute, entries[1].ContextMap()["elapsed"])
+}
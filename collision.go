@@ -0,0 +1,109 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ShadowedKeysKey is the zap field key of the array of keys whose value was
+// changed by Append, added when collision marking is enabled; see
+// [MarkCollisions].
+const ShadowedKeysKey string = "_shadowedKeys"
+
+// Collision describes a field appended to a context that already holds a field
+// with the same key but a different value, shadowing it.
+type Collision struct {
+	Key      string
+	Previous zap.Field
+	Current  zap.Field
+}
+
+// CollisionHandler is called for every collision detected by Append.
+type CollisionHandler func(ctx context.Context, collision Collision)
+
+// OnCollision registers handler to be called whenever Append shadows a field
+// with a different value, e.g. to log a warning about a trace_id changing in
+// the middle of a request. A nil handler disables reporting.
+func OnCollision(handler CollisionHandler) {
+	Configure(WithCollisionHandler(handler))
+}
+
+// MarkCollisions enables or disables recording the keys shadowed by Append in
+// a [ShadowedKeysKey] field, so that collisions are visible in the logs.
+func MarkCollisions(enabled bool) {
+	Configure(WithCollisionMarking(enabled))
+}
+
+// detectCollisions reports the fields of appended shadowing one of previous,
+// and returns the fields to store.
+func (c *config) detectCollisions(ctx context.Context, appended, previous []zap.Field) []zap.Field {
+	if c.onCollision == nil && !c.markCollisions {
+		return concatFields(appended, previous)
+	}
+	var shadowed []string
+	for _, field := range appended {
+		for _, prev := range previous {
+			if prev.Key != field.Key {
+				continue
+			}
+			if !prev.Equals(field) {
+				shadowed = append(shadowed, field.Key)
+				if c.onCollision != nil {
+					c.onCollision(ctx, Collision{Key: field.Key, Previous: prev, Current: field})
+				}
+			}
+			break
+		}
+	}
+	if !c.markCollisions || len(shadowed) == 0 {
+		return concatFields(appended, previous)
+	}
+	return markShadowed(appended, previous, shadowed)
+}
+
+// concatFields returns appended followed by previous in a new slice. Neither
+// may be appended to in place: appended belongs to the caller, who may reuse
+// its spare capacity for another context, and previous is shared with the
+// parent context and its other children.
+func concatFields(appended, previous []zap.Field) []zap.Field {
+	if len(appended)+len(previous) == 0 {
+		return nil
+	}
+	merged := make([]zap.Field, 0, len(appended)+len(previous))
+	merged = append(merged, appended...)
+	return append(merged, previous...)
+}
+
+// markShadowed merges appended and previous, along with a single marker
+// listing shadowed and the keys marked in previous.
+func markShadowed(appended, previous []zap.Field, shadowed []string) []zap.Field {
+	merged := make([]zap.Field, 0, len(appended)+len(previous)+1)
+	merged = append(merged, appended...)
+	markerAt := len(merged)
+	merged = append(merged, zap.Field{})
+	for _, prev := range previous {
+		if prev.Key == ShadowedKeysKey {
+			shadowed = append(shadowed, shadowedKeys(prev)...)
+			continue
+		}
+		merged = append(merged, prev)
+	}
+	merged[markerAt] = zap.Strings(ShadowedKeysKey, shadowed)
+	return merged
+}
+
+// shadowedKeys returns the keys listed by a [ShadowedKeysKey] marker.
+func shadowedKeys(marker zap.Field) []string {
+	enc := zapcore.NewMapObjectEncoder()
+	marker.AddTo(enc)
+	values, _ := enc.Fields[marker.Key].([]interface{})
+	keys := make([]string, 0, len(values))
+	for _, value := range values {
+		if key, ok := value.(string); ok {
+			keys = append(keys, key)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,48 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestOnCollision(t *testing.T) {
+	t.Cleanup(func() { OnCollision(nil) })
+
+	var collisions []Collision
+	OnCollision(func(_ context.Context, collision Collision) {
+		collisions = append(collisions, collision)
+	})
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, "first"), zap.Int("attempt", 1)})
+	ctx = Append(ctx, []zap.Field{zap.String(traceIDKey, "first")})
+	assert.Empty(t, collisions, "appending an identical field is not a collision")
+
+	Append(ctx, []zap.Field{zap.String(traceIDKey, "second"), zap.String(spanIDKey, "span")})
+	assert.Equal(t, []Collision{{
+		Key:      traceIDKey,
+		Previous: zap.String(traceIDKey, "first"),
+		Current:  zap.String(traceIDKey, "second"),
+	}}, collisions)
+}
+
+func TestMarkCollisions(t *testing.T) {
+	t.Cleanup(func() { MarkCollisions(false) })
+	MarkCollisions(true)
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, "first"), zap.Int("attempt", 1)})
+	ctx = Append(ctx, []zap.Field{zap.String(traceIDKey, "second")})
+	ctx = Append(ctx, []zap.Field{zap.Int("attempt", 2)})
+
+	assert.Equal(t, []zap.Field{
+		zap.Int("attempt", 2),
+		zap.Strings(ShadowedKeysKey, []string{"attempt", traceIDKey}),
+		zap.String(traceIDKey, "second"),
+		zap.String(traceIDKey, "first"),
+		zap.Int("attempt", 1),
+	}, GetAll(ctx))
+}
@@ -0,0 +1,96 @@
+package zax
+
+import (
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// config holds the behavior of a [Store], as configured by options; see
+// [Option]. A config is never modified once
+// published, so the hot path only pays for a single atomic load.
+type config struct {
+	limits      Limits
+	interning   bool
+	setHooks    []Hook
+	appendHooks []Hook
+	validator   KeyValidator
+	validation  ValidationMode
+
+	onCollision    CollisionHandler
+	markCollisions bool
+
+	errorStacktrace StacktracePolicy
+
+	redacted   map[string]bool
+	propagated map[string]bool
+
+	absentCounter AbsentCounter
+	stats         *statsCollector
+	provenance    bool
+	omitAbsent    bool
+	autoPrune     bool
+	keyMapper     KeyMapper
+	exporters     []Exporter
+	extractors    []Extractor
+	// defaults are the fields set by WithDefaults followed by the ones set by
+	// WithBuildInfo, merged by updateConfig.
+	defaults     []zap.Field
+	userDefaults []zap.Field
+	buildInfo    []zap.Field
+
+	deadlineFields bool
+	traceURL       string
+	sampling       *hashSampling
+	contextObject  string
+
+	placement        Placement
+	callSiteOverride bool
+
+	keyNormalizer  KeyMapper
+	bytesEncoding  BytesEncoding
+	bytesHashAbove int
+	clock          zapcore.Clock
+}
+
+var defaultConfig config
+
+func (s *Store) loadConfig() *config {
+	if c := s.config.Load(); c != nil {
+		return c
+	}
+	return &defaultConfig
+}
+
+// updateConfig publishes a copy of the store's config modified by fn.
+func (s *Store) updateConfig(fn func(*config)) {
+	for {
+		current := s.config.Load()
+		next := defaultConfig
+		if current != nil {
+			next = *current
+		}
+		fn(&next)
+		next.defaults = mergeDefaults(next.userDefaults, next.buildInfo)
+		if disabled {
+			// Nothing is attached in disabled builds, not even defaults.
+			next.defaults = nil
+		}
+		if s.config.CompareAndSwap(current, &next) {
+			return
+		}
+	}
+}
+
+// prepare validates and applies the configured transformations to fields
+// about to be written to a context; see [write].
+func (c *config) prepare(fields []zap.Field, strict bool) ([]zap.Field, error) {
+	fields, err := c.validateKeys(c.normalizeKeys(fields), strict)
+	if err != nil {
+		return nil, err
+	}
+	fields = c.redact(fields)
+	if c.interning {
+		fields = internFields(fields)
+	}
+	return fields, nil
+}
@@ -0,0 +1,95 @@
+package zax
+
+import (
+	"bytes"
+	"strings"
+
+	"go.uber.org/zap/buffer"
+	"go.uber.org/zap/zapcore"
+)
+
+const (
+	consoleContextColor = "\x1b[36m"
+	consoleColorReset   = "\x1b[0m"
+)
+
+// NewConsoleEncoder returns a console encoder for local development that
+// renders the fields attached to the logger, such as those attached by
+// [Logger], in a separate block after the fields of the call site, instead of
+// mixing both:
+//
+//	2024-05-01T10:00:00.000Z	INFO	order placed	{"order_id": 7}	ctx {"trace_id":"4bf92f35","user_id":"u-1"}
+//
+// With color, the block is colorized to stand out. cfg configures the console
+// encoder rendering the rest of the line, as for
+// [zapcore.NewConsoleEncoder].
+func NewConsoleEncoder(cfg zapcore.EncoderConfig, color bool) zapcore.Encoder {
+	lineEnding := cfg.LineEnding
+	if lineEnding == "" {
+		lineEnding = zapcore.DefaultLineEnding
+	}
+	if cfg.SkipLineEnding {
+		lineEnding = ""
+	}
+	return &consoleEncoder{
+		Encoder: zapcore.NewJSONEncoder(zapcore.EncoderConfig{
+			SkipLineEnding: true,
+			EncodeTime:     cfg.EncodeTime,
+			EncodeDuration: cfg.EncodeDuration,
+		}),
+		line:       zapcore.NewConsoleEncoder(cfg),
+		lineEnding: lineEnding,
+		color:      color,
+	}
+}
+
+// consoleEncoder encodes the attached fields with the embedded JSON encoder,
+// and entries with line.
+type consoleEncoder struct {
+	zapcore.Encoder
+	line       zapcore.Encoder
+	lineEnding string
+	color      bool
+}
+
+var emptyObject = []byte("{}")
+
+func (e *consoleEncoder) Clone() zapcore.Encoder {
+	clone := *e
+	clone.Encoder = e.Encoder.Clone()
+	return &clone
+}
+
+func (e *consoleEncoder) EncodeEntry(ent zapcore.Entry, fields []zapcore.Field) (*buffer.Buffer, error) {
+	line, err := e.line.EncodeEntry(ent, fields)
+	if err != nil {
+		return nil, err
+	}
+	attached, err := e.Encoder.EncodeEntry(zapcore.Entry{}, nil)
+	if err != nil {
+		line.Free()
+		return nil, err
+	}
+	defer attached.Free()
+	if bytes.Equal(attached.Bytes(), emptyObject) {
+		return line, nil
+	}
+
+	encoded := line.String()
+	line.Reset()
+	line.AppendString(strings.TrimSuffix(encoded, e.lineEnding))
+	line.AppendString("\tctx ")
+	if e.color {
+		line.AppendString(consoleContextColor)
+	}
+	_, _ = line.Write(attached.Bytes())
+	if e.color {
+		line.AppendString(consoleColorReset)
+	}
+	if strings.HasSuffix(encoded, e.lineEnding) {
+		line.AppendString(e.lineEnding)
+	}
+	return line, nil
+}
+
+var _ zapcore.Encoder = (*consoleEncoder)(nil)
@@ -0,0 +1,43 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func newConsoleLogger(buf *bytes.Buffer, color bool) *zap.Logger {
+	cfg := zapcore.EncoderConfig{MessageKey: "msg", LevelKey: "level", EncodeLevel: zapcore.CapitalLevelEncoder}
+	return zap.New(zapcore.NewCore(NewConsoleEncoder(cfg, color), zapcore.AddSync(buf), zapcore.DebugLevel))
+}
+
+func TestConsoleEncoder(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newConsoleLogger(&buf, false)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 2)})
+
+	Logger(ctx, logger).Info("order placed", zap.Int("order_id", 7))
+	Logger(ctx, logger).With(zap.Bool("retry", true)).Warn("retrying")
+	logger.Info("no context", zap.Int("order_id", 8))
+
+	assert.Equal(t, ""+
+		"INFO\torder placed\t{\"order_id\": 7}\tctx {\"trace_id\":\"test-trace-id-3333\",\"attempt\":2}\n"+
+		"WARN\tretrying\tctx {\"trace_id\":\"test-trace-id-3333\",\"attempt\":2,\"retry\":true}\n"+
+		"INFO\tno context\t{\"order_id\": 8}\n",
+		buf.String())
+}
+
+func TestConsoleEncoderColor(t *testing.T) {
+	var buf bytes.Buffer
+	logger := newConsoleLogger(&buf, true)
+
+	Logger(Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}), logger).Info("colored")
+
+	assert.Equal(t, "INFO\tcolored\tctx \x1b[36m{\"trace_id\":\"test-trace-id-3333\"}\x1b[0m\n", buf.String())
+}
@@ -0,0 +1,62 @@
+package zax
+
+import (
+	"context"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the fields added by [WithDeadlineFields].
+const (
+	DeadlineKey      = "deadline"
+	TimeRemainingKey = "time_remaining_ms"
+)
+
+// WithDeadlineFields enables or disables adding the deadline of the context
+// to the entries of loggers returned by [Logger], along with the time left
+// until it when each entry is logged, in milliseconds; negative once the
+// deadline passed. It helps correlating timeouts with the budget that was
+// left at every step. Contexts without a deadline get neither field.
+func WithDeadlineFields(enabled bool) Option {
+	return func(c *config) {
+		c.deadlineFields = enabled
+	}
+}
+
+// withDeadline returns logger adding the deadline fields of ctx, if any, to
+// its entries.
+func withDeadline(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &deadlineCore{Core: core, deadline: deadline}
+	}))
+}
+
+type deadlineCore struct {
+	zapcore.Core
+	deadline time.Time
+}
+
+func (c *deadlineCore) With(fields []zapcore.Field) zapcore.Core {
+	return &deadlineCore{Core: c.Core.With(fields), deadline: c.deadline}
+}
+
+func (c *deadlineCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkThrough(c.Core, ent, ent, ce, c.addFields)
+}
+
+// addFields returns fields followed by the deadline fields at the time of
+// ent.
+func (c *deadlineCore) addFields(ent zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	return append(fields[:len(fields):len(fields)],
+		zap.Time(DeadlineKey, c.deadline),
+		zap.Int64(TimeRemainingKey, c.deadline.Sub(ent.Time).Milliseconds()),
+	)
+}
+
+var _ zapcore.Core = (*deadlineCore)(nil)
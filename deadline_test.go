@@ -0,0 +1,62 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithDeadlineFields(t *testing.T) {
+	logs := newTestLogger(t)
+	store := NewStore(WithDeadlineFields(true))
+	deadline := time.Now().Add(time.Minute)
+	ctx, cancel := context.WithDeadline(store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}), deadline)
+	defer cancel()
+
+	store.Logger(ctx, logs.GetZapLogger()).Info("first")
+	store.Logger(context.Background(), logs.GetZapLogger()).Info("no deadline")
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, testTraceID, fields[traceIDKey])
+	assert.True(t, deadline.Equal(fields[DeadlineKey].(time.Time)))
+	remaining := fields[TimeRemainingKey].(int64)
+	assert.InDelta(t, time.Minute.Milliseconds(), remaining, float64(time.Second.Milliseconds()))
+	assert.NotContains(t, entries[1].ContextMap(), DeadlineKey)
+	assert.NotContains(t, entries[1].ContextMap(), TimeRemainingKey)
+}
+
+func TestWithDeadlineFieldsDisabled(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	NewStore().Logger(ctx, logs.GetZapLogger()).Info("entry")
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.NotContains(t, entries[0].ContextMap(), DeadlineKey)
+}
+
+func TestWithDeadlineFieldsKeepsSampling(t *testing.T) {
+	logger, logs := newSampledLogger(zapcore.InfoLevel)
+	store := NewStore(WithDeadlineFields(true))
+	ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+	defer cancel()
+
+	for i := 0; i < 5; i++ {
+		store.Logger(ctx, logger).Info("repeated")
+	}
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1)
+	assert.Contains(t, entries[0].ContextMap(), TimeRemainingKey)
+}
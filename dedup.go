@@ -0,0 +1,121 @@
+package zax
+
+import (
+	"context"
+	"hash/fnv"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Deduplicate returns logger with the fields stored in ctx attached, like
+// [Logger], that drops identical entries, with the same level, message and
+// fields, after n occurrences. Calling the returned done function, typically
+// deferred until the end of the request, logs a summary of the suppressed
+// entries, taming retry loops that would log thousands of identical lines:
+//
+//	logger, done := zax.Deduplicate(ctx, logger, 3)
+//	defer done()
+func Deduplicate(ctx context.Context, logger *zap.Logger, n int) (deduplicated *zap.Logger, done func()) {
+	base := Logger(ctx, logger)
+	state := &dedupState{limit: n, seen: make(map[uint64]*dedupEntry)}
+	deduplicated = base.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &dedupCore{Core: core, state: state}
+	}))
+	return deduplicated, func() { state.summarize(base) }
+}
+
+// dedupState counts the entries logged through the loggers of a
+// [Deduplicate] call.
+type dedupState struct {
+	limit int
+
+	mu    sync.Mutex
+	seen  map[uint64]*dedupEntry
+	order []*dedupEntry
+}
+
+type dedupEntry struct {
+	level   zapcore.Level
+	message string
+	count   int
+}
+
+// allow counts an entry with hash, reporting whether it is within the limit.
+func (s *dedupState) allow(hash uint64, ent zapcore.Entry) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	entry, ok := s.seen[hash]
+	if !ok {
+		entry = &dedupEntry{level: ent.Level, message: ent.Message}
+		s.seen[hash] = entry
+		s.order = append(s.order, entry)
+	}
+	entry.count++
+	return entry.count <= s.limit
+}
+
+// summarize logs the number of suppressed occurrences of each entry.
+func (s *dedupState) summarize(logger *zap.Logger) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, entry := range s.order {
+		if suppressed := entry.count - s.limit; suppressed > 0 {
+			if ce := logger.Check(entry.level, "suppressed duplicate log entries"); ce != nil {
+				ce.Write(zap.String("message", entry.message), zap.Int("suppressed", suppressed))
+			}
+		}
+	}
+	s.seen = make(map[uint64]*dedupEntry)
+	s.order = nil
+}
+
+type dedupCore struct {
+	zapcore.Core
+	state *dedupState
+	// with hashes the fields attached to the core.
+	with uint64
+}
+
+func (c *dedupCore) With(fields []zapcore.Field) zapcore.Core {
+	return &dedupCore{Core: c.Core.With(fields), state: c.state, with: hashFields(c.with, fields)}
+}
+
+func (c *dedupCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// Duplicates depend on the fields of the entry, only known by Write.
+	if c.Enabled(ent.Level) {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+func (c *dedupCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte{byte(ent.Level)})
+	_, _ = h.Write([]byte(ent.Message))
+	if !c.state.allow(hashFields(h.Sum64()^c.with, fields), ent) {
+		return nil
+	}
+	// Check the entries kept with the wrapped core, which may drop them too.
+	return writeChecked(c.Core.Check(ent, nil), ent, fields)
+}
+
+// hashFields returns a hash of fields, seeded by seed.
+func hashFields(seed uint64, fields []zapcore.Field) uint64 {
+	h := fnv.New64a()
+	var b [8]byte
+	for i := range b {
+		b[i] = byte(seed >> (8 * i))
+	}
+	_, _ = h.Write(b[:])
+	for _, field := range fields {
+		_, _ = h.Write([]byte(field.Key))
+		_, _ = h.Write([]byte{0})
+		_, _ = h.Write([]byte(textValue(field)))
+		_, _ = h.Write([]byte{0})
+	}
+	return h.Sum64()
+}
+
+var _ zapcore.Core = (*dedupCore)(nil)
@@ -0,0 +1,51 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestDeduplicate(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	logger, done := Deduplicate(ctx, logs.GetZapLogger(), 2)
+
+	for attempt := 0; attempt < 5; attempt++ {
+		logger.Warn("retrying", zap.String("reason", "timeout"))
+	}
+	logger.Warn("retrying", zap.String("reason", "refused"))
+	logger.With(zap.String("host", "a")).Warn("retrying", zap.String("reason", "timeout"))
+	logger.Error("retrying", zap.String("reason", "timeout"))
+
+	assert.Len(t, logs.GetRecordedLogs(), 5)
+	assert.Equal(t, testTraceID, logs.GetRecordedLogs()[0].ContextMap()[traceIDKey])
+
+	done()
+	entries := logs.GetRecordedLogs()
+	assert.Len(t, entries, 6)
+	summary := entries[5]
+	assert.Equal(t, "suppressed duplicate log entries", summary.Message)
+	assert.Equal(t, zapcore.WarnLevel, summary.Level)
+	assert.Equal(t, map[string]interface{}{traceIDKey: testTraceID, "message": "retrying", "suppressed": int64(3)}, summary.ContextMap())
+
+	done()
+	assert.Len(t, logs.GetRecordedLogs(), 6, "summaries are only logged once")
+}
+
+func TestDeduplicateKeepsSampling(t *testing.T) {
+	sampled, logs := newSampledLogger(zapcore.InfoLevel)
+	logger, done := Deduplicate(context.Background(), sampled, 3)
+	defer done()
+
+	for attempt := 0; attempt < 5; attempt++ {
+		logger.Warn("retrying")
+	}
+
+	assert.Equal(t, 1, logs.Len(), "entries kept by deduplication can still be sampled")
+}
@@ -0,0 +1,51 @@
+package zax
+
+import (
+	"fmt"
+	"os"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+var (
+	defaultLogger atomic.Pointer[zap.Logger]
+	// warnedUnset records that the default logger was used before being set.
+	warnedUnset atomic.Bool
+)
+
+// SetDefaultLogger sets the logger used by the context-aware logging
+// functions, such as [Info], when they are given a nil logger, so that call
+// sites don't need to be wired with one. A nil logger unsets it. It returns a
+// function restoring the previous default logger, like
+// [zap.ReplaceGlobals]:
+//
+//	defer zax.SetDefaultLogger(logger)()
+func SetDefaultLogger(logger *zap.Logger) (restore func()) {
+	prev := defaultLogger.Swap(logger)
+	return func() {
+		defaultLogger.Store(prev)
+	}
+}
+
+// DefaultLogger returns the logger set with [SetDefaultLogger]. Until one is
+// set, it returns a no-op logger, and warns once on standard error that
+// entries are dropped, so that a missing SetDefaultLogger doesn't go
+// unnoticed.
+func DefaultLogger() *zap.Logger {
+	if logger := defaultLogger.Load(); logger != nil {
+		return logger
+	}
+	if !warnedUnset.Swap(true) {
+		fmt.Fprintln(os.Stderr, "zax: default logger used before SetDefaultLogger; entries are dropped")
+	}
+	return zap.NewNop()
+}
+
+// orDefault returns logger, or the default logger if it is nil.
+func orDefault(logger *zap.Logger) *zap.Logger {
+	if logger == nil {
+		return DefaultLogger()
+	}
+	return logger
+}
@@ -0,0 +1,43 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestSetDefaultLogger(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	restore := SetDefaultLogger(zap.New(core))
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	Info(ctx, nil, "zero wiring")
+	Infof(ctx, nil, "formatted %d", 1)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "zero wiring", entries[0].Message)
+	assert.Equal(t, testTraceID, entries[0].ContextMap()[traceIDKey])
+	assert.Equal(t, "formatted 1", entries[1].Message)
+
+	restore()
+	assert.Nil(t, defaultLogger.Load())
+	Info(ctx, nil, "dropped")
+	assert.Equal(t, 2, logs.Len())
+}
+
+func TestDefaultLoggerUnset(t *testing.T) {
+	t.Cleanup(SetDefaultLogger(nil))
+	assert.NotPanics(t, func() {
+		Error(context.Background(), nil, "dropped")
+	})
+	assert.True(t, warnedUnset.Load(), "using the default logger before it is set warns")
+	assert.Equal(t, zap.NewNop().Core(), DefaultLogger().Core())
+}
@@ -0,0 +1,63 @@
+package zax
+
+import "go.uber.org/zap"
+
+// SetDefaults sets process-wide fields, such as the service name,
+// environment or region, merged beneath the fields of every context of the
+// default store on retrieval, so that every entry logged with [Logger] carries
+// the identity of the deployment without any middleware. Context fields with
+// the same keys take precedence. Calling it again replaces the defaults.
+//
+// Defaults stay in the process: they are not written by [Inject],
+// [MarshalJSON] or [MarshalBinary], so that they don't override the defaults
+// of the receiving process.
+func SetDefaults(fields ...zap.Field) {
+	Configure(WithDefaults(fields...))
+}
+
+// WithDefaults sets the fields merged beneath the fields of every context;
+// see [SetDefaults].
+func WithDefaults(fields ...zap.Field) Option {
+	defaults := append([]zap.Field(nil), fields...)
+	return func(c *config) {
+		c.userDefaults = defaults
+	}
+}
+
+// withDefaults returns fields followed by the defaults whose keys are not in
+// fields.
+func (c *config) withDefaults(fields []zap.Field) []zap.Field {
+	if len(c.defaults) == 0 {
+		return fields
+	}
+	merged := make([]zap.Field, len(fields), len(fields)+len(c.defaults))
+	copy(merged, fields)
+	for _, def := range c.defaults {
+		if !hasKey(fields, def.Key) {
+			merged = append(merged, def)
+		}
+	}
+	return merged
+}
+
+// mergeDefaults returns the fields set by WithDefaults followed by the build
+// information fields they don't override.
+func mergeDefaults(defaults, buildInfo []zap.Field) []zap.Field {
+	merged := concatFields(defaults, nil)
+	for _, field := range buildInfo {
+		if !hasKey(defaults, field.Key) {
+			merged = append(merged, field)
+		}
+	}
+	return merged
+}
+
+// hasKey reports whether a field of fields has key.
+func hasKey(fields []zap.Field, key string) bool {
+	for _, field := range fields {
+		if field.Key == key {
+			return true
+		}
+	}
+	return false
+}
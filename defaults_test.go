@@ -0,0 +1,46 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestSetDefaults(t *testing.T) {
+	t.Cleanup(func() { SetDefaults() })
+	SetDefaults(zap.String("service", "api"), zap.String("env", "prod"))
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("env", "canary")})
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.String("env", "canary"),
+		zap.String("service", "api"),
+	}, GetAll(ctx))
+	assert.Equal(t, []zap.Field{zap.String("service", "api"), zap.String("env", "prod")}, GetAll(context.Background()))
+
+	service, ok := GetField(ctx, "service")
+	assert.True(t, ok)
+	assert.Equal(t, zap.String("service", "api"), service)
+	env, _ := GetField(ctx, "env")
+	assert.Equal(t, zap.String("env", "canary"), env)
+
+	logs := newTestLogger(t)
+	Logger(context.Background(), logs.GetZapLogger()).Info("without fields")
+	Logger(ctx, logs.GetZapLogger()).Info("with fields")
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "api", entries[0].ContextMap()["service"])
+	assert.Equal(t, "canary", entries[1].ContextMap()["env"])
+
+	carrier := MapCarrier{}
+	Inject(ctx, carrier)
+	assert.NotContains(t, carrier, CarrierPrefix+"service", "defaults are not propagated")
+	data, err := MarshalJSON(ctx)
+	require.NoError(t, err)
+	assert.NotContains(t, string(data), "service")
+}
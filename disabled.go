@@ -0,0 +1,8 @@
+//go:build zax_disabled
+
+package zax
+
+// disabled is set by the zax_disabled build tag, under which writes are
+// no-ops and neither contexts nor loggers get fields, defaults included,
+// stripping contextual logging from latency-critical binaries.
+const disabled = true
@@ -0,0 +1,26 @@
+//go:build zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestDisabled(t *testing.T) {
+	ctx := context.Background()
+	assert.Equal(t, ctx, Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	assert.Equal(t, ctx, Append(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)}))
+
+	ctx = context.WithValue(ctx, loggerKey, (&container{}).with(nil, []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	assert.Empty(t, GetAll(ctx))
+	logger := zap.NewNop()
+	assert.Same(t, logger, Logger(ctx, logger))
+
+	store := NewStore(WithDefaults(zap.String("service", "api")))
+	assert.Empty(t, store.GetAll(ctx))
+	assert.Same(t, logger, store.Logger(ctx, logger))
+}
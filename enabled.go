@@ -0,0 +1,6 @@
+//go:build !zax_disabled
+
+package zax
+
+// disabled is set by the zax_disabled build tag; see disabled.go.
+const disabled = false
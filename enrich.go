@@ -0,0 +1,77 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Enricher returns a field when an entry is logged. Use [zap.Skip] to add
+// no field.
+type Enricher func() zap.Field
+
+// enrichersKey is the context key of the enrichers added by [WithEnrichers].
+type enrichersKey struct{}
+
+// WithEnrichers returns ctx with enrichers added to those of ctx. Loggers
+// returned by [Logger] for ctx and its children call them every time they
+// log an entry, rather than once when fields are stored, so that values
+// changing over the life of ctx, such as a retry attempt read from an atomic
+// counter, are current in every entry:
+//
+//	var attempt atomic.Int64
+//	ctx = zax.WithEnrichers(ctx, func() zap.Field {
+//		return zap.Int64("attempt", attempt.Load())
+//	})
+func WithEnrichers(ctx context.Context, enrichers ...Enricher) context.Context {
+	if len(enrichers) == 0 {
+		return ctx
+	}
+	parent := EnrichersFrom(ctx)
+	return context.WithValue(ctx, enrichersKey{}, append(parent[:len(parent):len(parent)], enrichers...))
+}
+
+// EnrichersFrom returns the enrichers of ctx added by [WithEnrichers], in the
+// order they were added.
+func EnrichersFrom(ctx context.Context) []Enricher {
+	enrichers, _ := ctx.Value(enrichersKey{}).([]Enricher)
+	return enrichers
+}
+
+// withEnrichers returns logger adding the fields of the enrichers of ctx, if
+// any, to its entries.
+func withEnrichers(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	enrichers := EnrichersFrom(ctx)
+	if len(enrichers) == 0 {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &enrichCore{Core: core, enrichers: enrichers}
+	}))
+}
+
+type enrichCore struct {
+	zapcore.Core
+	enrichers []Enricher
+}
+
+func (c *enrichCore) With(fields []zapcore.Field) zapcore.Core {
+	return &enrichCore{Core: c.Core.With(fields), enrichers: c.enrichers}
+}
+
+func (c *enrichCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkThrough(c.Core, ent, ent, ce, c.enrich)
+}
+
+// enrich returns fields followed by the fields of the enrichers.
+func (c *enrichCore) enrich(_ zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	enriched := make([]zapcore.Field, len(fields), len(fields)+len(c.enrichers))
+	copy(enriched, fields)
+	for _, enrich := range c.enrichers {
+		enriched = append(enriched, enrich())
+	}
+	return enriched
+}
+
+var _ zapcore.Core = (*enrichCore)(nil)
@@ -0,0 +1,76 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithEnrichers(t *testing.T) {
+	logs := newTestLogger(t)
+	var attempt atomic.Int64
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	ctx = WithEnrichers(ctx, func() zap.Field {
+		return zap.Int64("attempt", attempt.Load())
+	})
+	child := WithEnrichers(ctx, func() zap.Field { return zap.Skip() })
+	require.Len(t, EnrichersFrom(ctx), 1)
+	require.Len(t, EnrichersFrom(child), 2)
+
+	logger := Logger(child, logs.GetZapLogger())
+	for i := 0; i < 2; i++ {
+		attempt.Add(1)
+		logger.Info("attempt")
+	}
+	Logger(context.Background(), logs.GetZapLogger()).Info("plain")
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 3)
+	assert.Equal(t, int64(1), entries[0].ContextMap()["attempt"])
+	assert.Equal(t, int64(2), entries[1].ContextMap()["attempt"])
+	assert.Equal(t, testTraceID, entries[1].ContextMap()[traceIDKey])
+	assert.NotContains(t, entries[2].ContextMap(), "attempt")
+}
+
+func TestWithEnrichersDisabledLevel(t *testing.T) {
+	called := false
+	ctx := WithEnrichers(context.Background(), func() zap.Field {
+		called = true
+		return zap.Skip()
+	})
+
+	core, logs := observer.New(zapcore.InfoLevel)
+	Debug(ctx, zap.New(core), "dropped")
+
+	assert.Zero(t, logs.Len())
+
+	assert.False(t, called, "enrichers aren't called for dropped entries")
+}
+
+func TestWithEnrichersKeepsSampling(t *testing.T) {
+	calls := 0
+	ctx := WithEnrichers(context.Background(), func() zap.Field {
+		calls++
+		return zap.Int("calls", calls)
+	})
+	sampled, logs := newSampledLogger(zapcore.DebugLevel)
+	core, unsampled := observer.New(zapcore.DebugLevel)
+	dropAll := zap.New(NewTraceSampler(core, traceIDKey, 0))
+
+	for i := 0; i < 5; i++ {
+		Logger(ctx, sampled).Info("repeated")
+		Logger(ctx, dropAll).With(zap.String(traceIDKey, testTraceID)).Info("dropped")
+	}
+
+	assert.Equal(t, 1, logs.Len(), "entries dropped by the sampler stay dropped")
+	assert.Zero(t, unsampled.Len())
+	assert.Equal(t, 1, calls, "enrichers aren't called for dropped entries")
+}
@@ -0,0 +1,43 @@
+package zax
+
+import (
+	"context"
+	"os"
+	"os/exec"
+)
+
+// EnvKey is the environment variable through which fields are handed to
+// child processes.
+const EnvKey = "ZAX_FIELDS"
+
+// Environ returns an environment entry, in the "key=value" form used by
+// [exec.Cmd.Env], carrying the fields stored in ctx.
+func Environ(ctx context.Context) (string, error) {
+	header, err := EncodeHeader(ctx, 0)
+	if err != nil {
+		return "", err
+	}
+	return EnvKey + "=" + header, nil
+}
+
+// PrepareCmd adds the fields stored in ctx to the environment of cmd, so the
+// child process can restore them with [FromEnv]. If cmd.Env is nil, the child
+// keeps inheriting the environment of the current process.
+func PrepareCmd(ctx context.Context, cmd *exec.Cmd) error {
+	entry, err := Environ(ctx)
+	if err != nil {
+		return err
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, entry)
+	return nil
+}
+
+// FromEnv appends the fields handed down by the parent process, if any, to
+// ctx. It is meant to bootstrap the root context of a command spawned by a
+// service, so its logs keep the service's correlation fields.
+func FromEnv(ctx context.Context) (context.Context, error) {
+	return DecodeHeader(ctx, os.Getenv(EnvKey))
+}
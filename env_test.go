@@ -0,0 +1,34 @@
+package zax
+
+import (
+	"context"
+	"os/exec"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestEnvRoundTrip(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	cmd := exec.Command("child")
+	require.NoError(t, PrepareCmd(ctx, cmd))
+	entry := cmd.Env[len(cmd.Env)-1]
+	require.True(t, strings.HasPrefix(entry, EnvKey+"="))
+
+	t.Setenv(EnvKey, strings.TrimPrefix(entry, EnvKey+"="))
+	restored, err := FromEnv(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, GetAll(ctx), GetAll(restored))
+}
+
+func TestFromEnvUnset(t *testing.T) {
+	t.Setenv(EnvKey, "")
+	ctx := context.Background()
+	restored, err := FromEnv(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, restored)
+}
@@ -0,0 +1,98 @@
+package zax
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// MetadataKey is the metadata entry [Enqueue] stores the fields of the
+// enqueuing context under.
+const MetadataKey = "zax"
+
+// Envelope is a serializable job: its payload, along with the fields of the
+// context the job was submitted from. It lets fields travel with jobs that are
+// queued, persisted or handed to pooled workers, independently of any live
+// context chain.
+type Envelope[T any] struct {
+	// Fields are the fields of the submitting context, as produced by
+	// [MarshalJSON].
+	Fields json.RawMessage `json:"fields,omitempty"`
+	// Payload is the job itself.
+	Payload T `json:"payload"`
+}
+
+// NewEnvelope wraps payload along with the fields stored in ctx.
+func NewEnvelope[T any](ctx context.Context, payload T) (Envelope[T], error) {
+	envelope := Envelope[T]{Payload: payload}
+	if len(defaultStore.rawFields(ctx)) == 0 {
+		return envelope, nil
+	}
+	fields, err := MarshalJSON(ctx)
+	if err != nil {
+		return envelope, err
+	}
+	envelope.Fields = fields
+	return envelope, nil
+}
+
+// Context appends the fields carried by the envelope to ctx, typically the
+// context of the worker picking the job up.
+func (e Envelope[T]) Context(ctx context.Context) (context.Context, error) {
+	if len(e.Fields) == 0 {
+		return ctx, nil
+	}
+	return UnmarshalJSON(ctx, e.Fields)
+}
+
+// Bind captures the fields stored in ctx and returns a function that runs fn
+// with them appended to the context it is given. It suits in-process worker
+// pools, where jobs don't need to be serialized but the submitting context may
+// be long gone by the time a worker runs the job.
+func Bind(ctx context.Context, fn func(context.Context)) func(context.Context) {
+	fields := defaultStore.rawFields(ctx)
+	return func(workerCtx context.Context) {
+		if len(fields) > 0 {
+			workerCtx = Append(workerCtx, fields)
+		}
+		fn(workerCtx)
+	}
+}
+
+// Enqueue stores the fields of ctx in metadata, the free-form map most job
+// queues attach to jobs (e.g. machinery's Signature.Headers), under
+// [MetadataKey]. Fields are stored as a JSON string, so they survive brokers
+// that serialize metadata. A nil metadata map is allocated if ctx holds
+// fields; metadata is returned as is if it doesn't.
+func Enqueue[M ~map[string]any](ctx context.Context, metadata M) (M, error) {
+	if len(defaultStore.rawFields(ctx)) == 0 {
+		return metadata, nil
+	}
+	fields, err := MarshalJSON(ctx)
+	if err != nil {
+		return metadata, err
+	}
+	if metadata == nil {
+		metadata = make(M, 1)
+	}
+	metadata[MetadataKey] = string(fields)
+	return metadata, nil
+}
+
+// Dequeue appends the fields stored in metadata by [Enqueue] to ctx, typically
+// the context of the worker picking the job up. Metadata without fields leaves
+// ctx unchanged.
+func Dequeue[M ~map[string]any](ctx context.Context, metadata M) (context.Context, error) {
+	switch fields := metadata[MetadataKey].(type) {
+	case nil:
+		return ctx, nil
+	case string:
+		return UnmarshalJSON(ctx, []byte(fields))
+	case []byte:
+		return UnmarshalJSON(ctx, fields)
+	case json.RawMessage:
+		return UnmarshalJSON(ctx, fields)
+	default:
+		return ctx, fmt.Errorf("zax: dequeue: unexpected %T metadata", fields)
+	}
+}
@@ -0,0 +1,108 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+type testJob struct {
+	ID int `json:"id"`
+}
+
+func TestEnvelope(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 2)})
+
+	envelope, err := NewEnvelope(ctx, testJob{ID: 7})
+	require.NoError(t, err)
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+
+	var received Envelope[testJob]
+	require.NoError(t, json.Unmarshal(data, &received))
+	assert.Equal(t, testJob{ID: 7}, received.Payload)
+
+	workerCtx := Set(context.Background(), []zap.Field{zap.String("worker", "w1")})
+	jobCtx, err := received.Context(workerCtx)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Int64("attempt", 2),
+		zap.String("worker", "w1"),
+	}, GetAll(jobCtx))
+}
+
+func TestEnvelopeWithoutFields(t *testing.T) {
+	envelope, err := NewEnvelope(context.Background(), testJob{ID: 1})
+	require.NoError(t, err)
+
+	data, err := json.Marshal(envelope)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"payload":{"id":1}}`, string(data))
+
+	ctx := context.Background()
+	jobCtx, err := envelope.Context(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, jobCtx)
+}
+
+func TestBind(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	var got []zap.Field
+	job := Bind(ctx, func(ctx context.Context) {
+		got = GetAll(ctx)
+	})
+	job(Set(context.Background(), []zap.Field{zap.String("worker", "w1")}))
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("worker", "w1")}, got)
+}
+
+type testHeaders map[string]interface{}
+
+func TestEnqueue(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 2)})
+
+	headers, err := Enqueue(ctx, testHeaders(nil))
+	require.NoError(t, err)
+	require.Contains(t, headers, MetadataKey)
+
+	// Brokers typically serialize metadata along with the job.
+	data, err := json.Marshal(headers)
+	require.NoError(t, err)
+	var received testHeaders
+	require.NoError(t, json.Unmarshal(data, &received))
+
+	jobCtx, err := Dequeue(Set(context.Background(), []zap.Field{zap.String("worker", "w1")}), received)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Int64("attempt", 2),
+		zap.String("worker", "w1"),
+	}, GetAll(jobCtx))
+}
+
+func TestEnqueueWithoutFields(t *testing.T) {
+	headers, err := Enqueue(context.Background(), map[string]any(nil))
+	require.NoError(t, err)
+	assert.Nil(t, headers)
+
+	ctx := context.Background()
+	jobCtx, err := Dequeue(ctx, headers)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, jobCtx)
+}
+
+func TestDequeueInvalid(t *testing.T) {
+	_, err := Dequeue(context.Background(), map[string]any{MetadataKey: 42})
+	assert.EqualError(t, err, "zax: dequeue: unexpected int metadata")
+
+	_, err = Dequeue(context.Background(), map[string]any{MetadataKey: "{"})
+	assert.Error(t, err)
+}
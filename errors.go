@@ -0,0 +1,140 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldsError is implemented by errors carrying zap fields, such as the ones
+// returned by [WrapError]. [FieldsFromError] collects the fields of any error
+// implementing it.
+type FieldsError interface {
+	error
+	Fields() []zap.Field
+}
+
+// fieldsError is an error carrying the fields of the context it was wrapped
+// in; see [WrapError].
+type fieldsError struct {
+	err    error
+	fields []zap.Field
+}
+
+// WrapError returns an error wrapping err along with a snapshot of the fields
+// stored in ctx, so that the fields of the request that failed are still at
+// hand when the error is eventually logged, possibly far away from it. It
+// returns nil if err is nil.
+//
+// The returned error has the same message as err, and unwraps to it.
+func WrapError(ctx context.Context, err error) error {
+	if err == nil {
+		return nil
+	}
+	return &fieldsError{err: err, fields: GetAll(ctx)}
+}
+
+func (e *fieldsError) Error() string {
+	return e.err.Error()
+}
+
+func (e *fieldsError) Unwrap() error {
+	return e.err
+}
+
+// Fields returns the fields captured when the error was wrapped.
+func (e *fieldsError) Fields() []zap.Field {
+	return e.fields
+}
+
+// FieldsFromError collects the fields carried by err and the errors it wraps,
+// following both errors.Unwrap and errors.Join chains. Fields are merged from
+// the outermost error to the innermost ones, keeping only the first field with
+// a given key.
+func FieldsFromError(err error) []zap.Field {
+	var fields []zap.Field
+	walkErrors(err, func(err error) {
+		if carrier, ok := err.(FieldsError); ok {
+			fields = append(fields, carrier.Fields()...)
+		}
+	})
+	return uniqueFields(fields)
+}
+
+// walkErrors calls fn for err and every error it wraps, depth first.
+func walkErrors(err error, fn func(error)) {
+	if err == nil {
+		return
+	}
+	fn(err)
+	switch wrapper := err.(type) {
+	case interface{ Unwrap() error }:
+		walkErrors(wrapper.Unwrap(), fn)
+	case interface{ Unwrap() []error }:
+		for _, wrapped := range wrapper.Unwrap() {
+			walkErrors(wrapped, fn)
+		}
+	}
+}
+
+// uniqueFields returns fields without the fields whose key appeared earlier,
+// in their original order.
+func uniqueFields(fields []zap.Field) []zap.Field {
+	if len(fields) < 2 {
+		return fields
+	}
+	return pruneFields(fields, make(map[string]struct{}, len(fields)), nil)
+}
+
+// StacktracePolicy decides whether [LogError] records a stack trace.
+type StacktracePolicy int
+
+const (
+	// StacktraceDefault leaves the decision to the logger's own
+	// configuration, see [zap.AddStacktrace].
+	StacktraceDefault StacktracePolicy = iota
+	// StacktraceAlways records a stack trace.
+	StacktraceAlways
+	// StacktraceNever never records a stack trace.
+	StacktraceNever
+)
+
+// noStacktrace is above every level, so that no entry gets a stack trace.
+const noStacktrace = zapcore.FatalLevel + 1
+
+// SetErrorStacktrace sets the stack trace policy of [LogError].
+func SetErrorStacktrace(policy StacktracePolicy) {
+	Configure(WithErrorStacktrace(policy))
+}
+
+// LogError logs err at error level with a consistent shape: the message of
+// err, the extra fields, the fields stored in ctx, the fields carried by err
+// (see [FieldsFromError]) and err itself under the "error" key. The fields
+// stored in ctx are attached with [Logger], so that sampling and enrichers
+// apply as to any entry logged with ctx. The fields carried by err are left
+// out when the extra fields or ctx have their key. Whether a stack trace is
+// recorded depends on the policy set by [SetErrorStacktrace]. Nothing is
+// logged if err is nil. A nil logger stands for the default logger; see
+// [SetDefaultLogger].
+func LogError(ctx context.Context, logger *zap.Logger, err error, extra ...zap.Field) {
+	if err == nil {
+		return
+	}
+	seen := make(map[string]struct{}, len(extra))
+	fields := pruneFields(extra, seen, nil)
+	for _, field := range View(ctx).Unsafe() {
+		seen[field.Key] = struct{}{}
+	}
+	fields = append(fields, pruneFields(append(FieldsFromError(err), zap.Error(err)), seen, nil)...)
+
+	logger = Logger(ctx, orDefault(logger))
+	logger = logger.WithOptions(zap.AddCallerSkip(1))
+	switch defaultStore.loadConfig().errorStacktrace {
+	case StacktraceAlways:
+		logger = logger.WithOptions(zap.AddStacktrace(zapcore.DebugLevel))
+	case StacktraceNever:
+		logger = logger.WithOptions(zap.AddStacktrace(noStacktrace))
+	}
+	logger.Error(err.Error(), fields...)
+}
@@ -0,0 +1,120 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWrapError(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	assert.NoError(t, WrapError(ctx, nil))
+
+	err := WrapError(ctx, io.EOF)
+	assert.EqualError(t, err, io.EOF.Error())
+	assert.ErrorIs(t, err, io.EOF)
+
+	var carrier interface{ Fields() []zap.Field }
+	if assert.True(t, errors.As(err, &carrier)) {
+		assert.Equal(t, GetAll(ctx), carrier.Fields())
+	}
+}
+
+type customError struct{}
+
+func (customError) Error() string { return "custom" }
+func (customError) Fields() []zap.Field {
+	return []zap.Field{zap.String("component", "custom"), zap.String(traceIDKey, "custom")}
+}
+
+func TestFieldsFromError(t *testing.T) {
+	requestCtx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("user_id", "u1")})
+	jobCtx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, "job-trace"), zap.String("job_id", "j1")})
+
+	err := WrapError(requestCtx, fmt.Errorf("handle: %w", errors.Join(
+		WrapError(jobCtx, io.EOF),
+		customError{},
+	)))
+
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.String("user_id", "u1"),
+		zap.String("job_id", "j1"),
+		zap.String("component", "custom"),
+	}, FieldsFromError(err))
+
+	assert.Empty(t, FieldsFromError(nil))
+	assert.Empty(t, FieldsFromError(io.EOF))
+}
+
+func TestLogError(t *testing.T) {
+	t.Cleanup(func() { SetErrorStacktrace(StacktraceDefault) })
+
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core, zap.AddCaller())
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("user_id", "u1")})
+	err := WrapError(Set(ctx, []zap.Field{zap.String("job_id", "j1"), zap.String(traceIDKey, "stale")}), io.EOF)
+
+	LogError(ctx, logger, nil)
+	LogError(ctx, logger, err, zap.String("user_id", "override"))
+
+	SetErrorStacktrace(StacktraceAlways)
+	LogError(ctx, logger, err)
+	SetErrorStacktrace(StacktraceNever)
+	LogError(ctx, zap.New(core, zap.AddStacktrace(zapcore.ErrorLevel)), err)
+
+	entries := recorded.All()
+	if !assert.Len(t, entries, 3) {
+		return
+	}
+	assert.Equal(t, zapcore.ErrorLevel, entries[0].Level)
+	assert.Equal(t, io.EOF.Error(), entries[0].Message)
+	assert.Contains(t, entries[0].Caller.File, "errors_test.go")
+	assert.Equal(t, map[string]any{
+		"user_id":  "override",
+		traceIDKey: testTraceID,
+		"job_id":   "j1",
+		"error":    io.EOF.Error(),
+	}, entries[0].ContextMap())
+	assert.Empty(t, entries[0].Stack)
+	assert.NotEmpty(t, entries[1].Stack)
+	assert.Empty(t, entries[2].Stack)
+}
+
+func TestLogErrorWithEnrichers(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	ctx = WithEnrichers(ctx, func() zap.Field { return zap.Int("attempt", 2) })
+
+	LogError(ctx, logs.GetZapLogger(), WrapError(Set(ctx, []zap.Field{zap.String(traceIDKey, "stale")}), io.EOF))
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, map[string]any{
+		traceIDKey: testTraceID,
+		"attempt":  int64(2),
+		"error":    io.EOF.Error(),
+	}, entries[0].ContextMap())
+}
+
+func TestLogErrorDefaultLogger(t *testing.T) {
+	logs := newTestLogger(t)
+	defer SetDefaultLogger(logs.GetZapLogger())()
+
+	LogError(context.Background(), nil, io.EOF)
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, io.EOF.Error(), entries[0].Message)
+}
@@ -0,0 +1,53 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Change describes a write of fields, as seen by an [Exporter].
+type Change struct {
+	// Appended tells whether the fields were appended rather than set.
+	Appended bool
+	// Written are the written fields.
+	Written []zap.Field
+	// Fields are all the fields in the context after the write. Each exporter
+	// gets its own copy, which it is free to modify.
+	Fields []zap.Field
+}
+
+// Exporter mirrors the fields written to contexts to a secondary system, such
+// as a request-scoped debug buffer or the scope of a crash reporter,
+// independently of whether any entry is ever logged.
+type Exporter interface {
+	// Export is called after every Set and Append with the resulting context.
+	Export(ctx context.Context, change Change)
+}
+
+// ExporterFunc adapts a function to an [Exporter].
+type ExporterFunc func(context.Context, Change)
+
+// Export calls f.
+func (f ExporterFunc) Export(ctx context.Context, change Change) {
+	f(ctx, change)
+}
+
+// WithExporter adds an exporter called after every Set and Append. Unlike
+// hooks, exporters receive the context resulting from the write, holding
+// the written fields.
+func WithExporter(exporter Exporter) Option {
+	return func(c *config) {
+		c.exporters = append(c.exporters[:len(c.exporters):len(c.exporters)], exporter)
+	}
+}
+
+// export calls exporters with a change, each with a copy of its fields so
+// that exporters can't alter the context.
+func export(ctx context.Context, exporters []Exporter, change Change) {
+	fields := change.Fields
+	for _, exporter := range exporters {
+		change.Fields = concatFields(fields, nil)
+		exporter.Export(ctx, change)
+	}
+}
@@ -0,0 +1,46 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestWithExporter(t *testing.T) {
+	var changes []Change
+	var exported []zap.Field
+	store := NewStore()
+	store.Configure(WithExporter(ExporterFunc(func(ctx context.Context, change Change) {
+		changes = append(changes, change)
+		exported = store.GetAll(ctx)
+	})))
+
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	store.Append(ctx, []zap.Field{zap.String(spanIDKey, "span")})
+
+	assert.Equal(t, []Change{
+		{
+			Written: []zap.Field{zap.String(traceIDKey, testTraceID)},
+			Fields:  []zap.Field{zap.String(traceIDKey, testTraceID)},
+		},
+		{
+			Appended: true,
+			Written:  []zap.Field{zap.String(spanIDKey, "span")},
+			Fields:   []zap.Field{zap.String(spanIDKey, "span"), zap.String(traceIDKey, testTraceID)},
+		},
+	}, changes)
+	assert.Equal(t, changes[1].Fields, exported, "exporters receive the resulting context")
+}
+
+func TestExporterCannotAlterContext(t *testing.T) {
+	store := NewStore(WithExporter(ExporterFunc(func(_ context.Context, change Change) {
+		change.Fields[0] = zap.String(traceIDKey, "tampered")
+	})))
+
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.GetAll(ctx))
+}
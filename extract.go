@@ -0,0 +1,52 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Extractor derives fields from the values of a context, such as the active
+// span, the authenticated principal or the request, so that packages can
+// contribute fields without depending on zax.
+type Extractor func(context.Context) []zap.Field
+
+// RegisterExtractor adds an extractor called by every [ResolveAll] on the
+// default store.
+func RegisterExtractor(extractor Extractor) {
+	Configure(WithExtractor(extractor))
+}
+
+// WithExtractor adds an extractor called by every ResolveAll; see
+// [RegisterExtractor].
+func WithExtractor(extractor Extractor) Option {
+	return func(c *config) {
+		c.extractors = append(c.extractors[:len(c.extractors):len(c.extractors)], extractor)
+	}
+}
+
+// ResolveAll returns the fields stored in ctx, like [GetAll], followed by the
+// fields derived from ctx by the registered extractors, in registration
+// order. Stored fields take precedence over extracted fields with the same
+// key.
+func ResolveAll(ctx context.Context) []zap.Field {
+	return defaultStore.ResolveAll(ctx)
+}
+
+// ResolveAll returns the fields of s in ctx along with the fields derived
+// by the extractors of s; see [ResolveAll].
+func (s *Store) ResolveAll(ctx context.Context) []zap.Field {
+	resolved := s.GetAll(ctx)
+	cfg := s.loadConfig()
+	if len(cfg.extractors) == 0 {
+		return resolved
+	}
+	seen := make(map[string]struct{}, len(resolved))
+	for _, field := range resolved {
+		seen[field.Key] = struct{}{}
+	}
+	for _, extractor := range cfg.extractors {
+		resolved = append(resolved, pruneFields(cfg.mapFields(extractor(ctx)), seen, nil)...)
+	}
+	return resolved
+}
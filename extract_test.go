@@ -0,0 +1,39 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type principalKey struct{}
+
+func TestResolveAll(t *testing.T) {
+	store := NewStore()
+	ctx := context.WithValue(context.Background(), principalKey{}, "alice")
+	ctx = store.Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+	assert.Equal(t, store.GetAll(ctx), store.ResolveAll(ctx))
+
+	store.Configure(
+		WithExtractor(func(ctx context.Context) []zap.Field {
+			if principal, ok := ctx.Value(principalKey{}).(string); ok {
+				return []zap.Field{zap.String("user_id", principal)}
+			}
+			return nil
+		}),
+		WithExtractor(func(context.Context) []zap.Field {
+			return []zap.Field{zap.String(traceIDKey, "shadowed"), zap.String("user_id", "shadowed"), zap.Int("pid", 1)}
+		}),
+	)
+
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.String("user_id", "alice"),
+		zap.Int("pid", 1),
+	}, store.ResolveAll(ctx))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.GetAll(ctx), "GetAll doesn't extract")
+}
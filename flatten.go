@@ -0,0 +1,114 @@
+package zax
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Flatten returns fields with object and map-valued fields replaced by one
+// field per leaf value, keyed by the dotted path to it: a zap.Object("http",
+// ...) with a method becomes an "http.method" field. Other fields are kept
+// as-is. It suits backends preferring flat documents; see [Nest] for the
+// converse.
+func Flatten(fields []zap.Field) []zap.Field {
+	flat := make([]zap.Field, 0, len(fields))
+	for _, field := range fields {
+		value, ok := objectValue(field)
+		if !ok {
+			flat = append(flat, field)
+			continue
+		}
+		flat = flattenValue(flat, field.Key, value)
+	}
+	return flat
+}
+
+// objectValue returns the value of field as a map, if it is an object or a
+// map with string keys.
+func objectValue(field zap.Field) (map[string]interface{}, bool) {
+	switch field.Type {
+	case zapcore.ObjectMarshalerType, zapcore.ReflectType:
+	default:
+		return nil, false
+	}
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	return asMap(enc.Fields[field.Key])
+}
+
+// asMap converts value to a map, if it is a map with string keys.
+func asMap(value interface{}) (map[string]interface{}, bool) {
+	if m, ok := value.(map[string]interface{}); ok {
+		return m, true
+	}
+	v := reflect.ValueOf(value)
+	if v.Kind() != reflect.Map || v.Type().Key().Kind() != reflect.String {
+		return nil, false
+	}
+	m := make(map[string]interface{}, v.Len())
+	for iter := v.MapRange(); iter.Next(); {
+		m[iter.Key().String()] = iter.Value().Interface()
+	}
+	return m, true
+}
+
+// flattenValue appends the leaves of value, keyed by their path from prefix,
+// in key order.
+func flattenValue(flat []zap.Field, prefix string, value map[string]interface{}) []zap.Field {
+	keys := make([]string, 0, len(value))
+	for key := range value {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		path := prefix + "." + key
+		if nested, ok := asMap(value[key]); ok {
+			flat = flattenValue(flat, path, nested)
+			continue
+		}
+		flat = append(flat, zap.Any(path, value[key]))
+	}
+	return flat
+}
+
+// Nest returns fields with the fields with dotted keys grouped into objects:
+// "http.method" and "http.status" fields become a single zap.Object("http",
+// ...) field. Groups take the position of their first field, and keep their
+// fields in order. It suits backends preferring nested documents; see
+// [Flatten] for the converse.
+func Nest(fields []zap.Field) []zap.Field {
+	nested := make([]zap.Field, 0, len(fields))
+	// groups holds, by prefix, the position of the group in nested and its
+	// fields.
+	type group struct {
+		at     int
+		fields []zap.Field
+	}
+	groups := make(map[string]*group)
+	var prefixes []string
+	for _, field := range fields {
+		prefix, rest, ok := strings.Cut(field.Key, ".")
+		if !ok || prefix == "" || rest == "" {
+			nested = append(nested, field)
+			continue
+		}
+		g, found := groups[prefix]
+		if !found {
+			g = &group{at: len(nested)}
+			groups[prefix] = g
+			prefixes = append(prefixes, prefix)
+			nested = append(nested, zap.Field{})
+		}
+		field.Key = rest
+		g.fields = append(g.fields, field)
+	}
+	for _, prefix := range prefixes {
+		g := groups[prefix]
+		nested[g.at] = zap.Object(prefix, fieldsObject(Nest(g.fields)))
+	}
+	return nested
+}
@@ -0,0 +1,64 @@
+package zax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFlatten(t *testing.T) {
+	request := zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("method", "GET")
+		enc.AddInt("status", 200)
+		return enc.AddObject("client", zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("ip", "10.0.0.1")
+			return nil
+		}))
+	})
+
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Any("http.client.ip", "10.0.0.1"),
+		zap.Any("http.method", "GET"),
+		zap.Any("http.status", int64(200)),
+		zap.Any("labels.team", "core"),
+		zap.Strings("tags", []string{"a"}),
+	}, Flatten([]zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Object("http", request),
+		zap.Any("labels", map[string]string{"team": "core"}),
+		zap.Strings("tags", []string{"a"}),
+	}))
+}
+
+func TestNest(t *testing.T) {
+	nested := Nest([]zap.Field{
+		zap.String("http.method", "GET"),
+		zap.String(traceIDKey, testTraceID),
+		zap.String("http.client.ip", "10.0.0.1"),
+		zap.Int("http.status", 200),
+		zap.String(".hidden", "kept"),
+	})
+
+	enc := zapcore.NewMapObjectEncoder()
+	for _, field := range nested {
+		field.AddTo(enc)
+	}
+	assert.Equal(t, map[string]interface{}{
+		"http": map[string]interface{}{
+			"method": "GET",
+			"client": map[string]interface{}{"ip": "10.0.0.1"},
+			"status": int64(200),
+		},
+		traceIDKey: testTraceID,
+		".hidden":  "kept",
+	}, enc.Fields)
+	assert.Equal(t, []string{"http", traceIDKey, ".hidden"}, WriteEvent{Fields: nested}.Keys())
+}
+
+func TestFlattenNestRoundTrip(t *testing.T) {
+	fields := []zap.Field{zap.String("http.method", "GET"), zap.String("http.path", "/")}
+	assert.Equal(t, []zap.Field{zap.Any("http.method", "GET"), zap.Any("http.path", "/")}, Flatten(Nest(fields)))
+}
@@ -0,0 +1,31 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Fork returns a copy of ctx holding the fields stored in ctx mapped by
+// transform, leaving out those for which it returns false. It hands a
+// sanitized or renamed view of the fields to a sub-component; scopes are
+// flattened, and the forked fields are written like by [Set].
+func Fork(ctx context.Context, transform func(zap.Field) (zap.Field, bool)) context.Context {
+	return defaultStore.Fork(ctx, transform)
+}
+
+// Fork returns a copy of ctx with the fields of s mapped by transform; see
+// [Fork].
+func (s *Store) Fork(ctx context.Context, transform func(zap.Field) (zap.Field, bool)) context.Context {
+	fields := s.rawFields(ctx)
+	forked := make([]zap.Field, 0, len(fields))
+	for _, field := range fields {
+		if field, ok := transform(field); ok {
+			forked = append(forked, field)
+		}
+	}
+	// Detach the fields of ctx so that the forked fields replace all of them.
+	ctx = context.WithValue(ctx, s.key, (*container)(nil))
+	ctx, _ = s.write(ctx, nil, forked, opSet, false)
+	return ctx
+}
@@ -0,0 +1,38 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestFork(t *testing.T) {
+	scope := NewScope("request")
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("password", "hunter2")})
+	ctx = scope.Set(ctx, []zap.Field{zap.String("user_id", "alice")})
+
+	forked := Fork(ctx, func(field zap.Field) (zap.Field, bool) {
+		if field.Key == "password" {
+			return field, false
+		}
+		field.Key = "upstream." + field.Key
+		return field, true
+	})
+
+	assert.Equal(t, []zap.Field{
+		zap.String("upstream."+traceIDKey, testTraceID),
+		zap.String("upstream.user_id", "alice"),
+	}, GetAll(forked))
+	assert.Empty(t, scope.Get(forked), "scopes are flattened")
+	assert.Len(t, GetAll(ctx), 3, "the original context is untouched")
+
+	empty := Fork(ctx, func(field zap.Field) (zap.Field, bool) {
+		return field, strings.HasPrefix(field.Key, "missing")
+	})
+	assert.Empty(t, GetAll(empty))
+}
@@ -0,0 +1,70 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// frameKey keys the frame stack of the store with key.
+type frameKey struct {
+	key any
+}
+
+// frame records the fields of a store before a [Push], so that [Pop] can
+// restore them.
+type frame struct {
+	base   *container
+	parent *frame
+}
+
+// Push appends fields to the fields stored in ctx as a new frame, which [Pop]
+// unwinds. Frames let nested operations, such as the steps of a pipeline,
+// layer their fields on top of their caller's; [GetAll] lists the fields of
+// the top frame first, down to the fields stored before the first Push.
+func Push(ctx context.Context, fields ...zap.Field) context.Context {
+	return defaultStore.Push(ctx, fields...)
+}
+
+// Push appends fields to the store in ctx as a new frame; see [Push].
+func (s *Store) Push(ctx context.Context, fields ...zap.Field) context.Context {
+	f := &frame{base: s.fromContext(ctx), parent: s.frame(ctx)}
+	return s.Append(context.WithValue(ctx, frameKey{s.key}, f), fields)
+}
+
+// Pop returns ctx with the top frame unwound: the fields stored in ctx are
+// restored to what they were before the matching [Push], dropping the fields
+// written since, whether pushed or set. ctx is returned as is if it holds no
+// frame.
+func Pop(ctx context.Context) context.Context {
+	return defaultStore.Pop(ctx)
+}
+
+// Pop unwinds the top frame of the store in ctx; see [Pop].
+func (s *Store) Pop(ctx context.Context) context.Context {
+	f := s.frame(ctx)
+	if f == nil {
+		return ctx
+	}
+	ctx = context.WithValue(ctx, frameKey{s.key}, f.parent)
+	return context.WithValue(ctx, s.key, f.base)
+}
+
+// Depth returns the number of frames pushed in ctx and not popped yet.
+func Depth(ctx context.Context) int {
+	return defaultStore.Depth(ctx)
+}
+
+// Depth returns the number of frames of the store in ctx; see [Depth].
+func (s *Store) Depth(ctx context.Context) int {
+	depth := 0
+	for f := s.frame(ctx); f != nil; f = f.parent {
+		depth++
+	}
+	return depth
+}
+
+func (s *Store) frame(ctx context.Context) *frame {
+	f, _ := ctx.Value(frameKey{s.key}).(*frame)
+	return f
+}
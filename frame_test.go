@@ -0,0 +1,52 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestPushPop(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	step := Push(ctx, zap.String("step", "parse"))
+	sub := Push(step, zap.String("substep", "tokenize"), zap.Int("line", 3))
+	sub = Append(sub, []zap.Field{zap.Int("column", 7)})
+	assert.Equal(t, 2, Depth(sub))
+	assert.Equal(t, []zap.Field{
+		zap.Int("column", 7),
+		zap.String("substep", "tokenize"),
+		zap.Int("line", 3),
+		zap.String("step", "parse"),
+		zap.String(traceIDKey, testTraceID),
+	}, GetAll(sub))
+
+	popped := Pop(sub)
+	assert.Equal(t, 1, Depth(popped))
+	assert.Equal(t, GetAll(step), GetAll(popped))
+
+	popped = Pop(popped)
+	assert.Equal(t, 0, Depth(popped))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(popped))
+	assert.Equal(t, popped, Pop(popped), "popping without frames is a no-op")
+}
+
+func TestPopFirstFrame(t *testing.T) {
+	ctx := Pop(Push(context.Background(), zap.String("step", "parse")))
+	assert.Empty(t, GetAll(ctx))
+	assert.Zero(t, Depth(ctx))
+}
+
+func TestPushStores(t *testing.T) {
+	store := NewStore()
+	ctx := Push(context.Background(), zap.String("step", "parse"))
+	ctx = store.Push(ctx, zap.String("step", "lex"))
+
+	assert.Equal(t, 1, Depth(store.Pop(ctx)))
+	assert.Equal(t, []zap.Field{zap.String("step", "parse")}, GetAll(store.Pop(ctx)))
+	assert.Empty(t, store.GetAll(store.Pop(ctx)))
+}
@@ -0,0 +1,53 @@
+package zax
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"runtime/debug"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Go runs fn in a new goroutine, covering the common "spawn a worker from a
+// request" pattern. fn receives a context that keeps the values of ctx,
+// including its fields, but is not canceled when ctx is, so the work can
+// outlive the request.
+//
+// A panic in fn is recovered and logged, along with the fields, through the
+// default logger (see [SetDefaultLogger]), or zap's global logger (see
+// [zap.L]) if none is set, instead of crashing the process. If neither logs
+// errors, the panic and its stack are written to standard error, so that it
+// is never silently dropped.
+func Go(ctx context.Context, fn func(context.Context)) {
+	detached := context.WithoutCancel(ctx)
+	go func() {
+		defer recoverAndLog(detached)
+		fn(detached)
+	}()
+}
+
+// panicOutput is where recovered panics are written when no logger logs
+// them.
+var panicOutput io.Writer = os.Stderr
+
+func recoverAndLog(ctx context.Context) {
+	r := recover()
+	if r == nil {
+		return
+	}
+	logger := defaultLogger.Load()
+	if logger == nil {
+		logger = zap.L()
+	}
+	if !logger.Core().Enabled(zapcore.ErrorLevel) {
+		fmt.Fprintf(panicOutput, "zax: recovered from panic in goroutine: %v\n%s", r, debug.Stack())
+		return
+	}
+	Logger(ctx, logger).Error("recovered from panic in goroutine",
+		zap.Any("panic", r),
+		zap.ByteString("stack", debug.Stack()),
+	)
+}
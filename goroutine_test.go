@@ -0,0 +1,102 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestGo(t *testing.T) {
+	parent, cancel := context.WithCancel(Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}))
+	cancel()
+
+	done := make(chan context.Context)
+	Go(parent, func(ctx context.Context) {
+		done <- ctx
+	})
+	ctx := <-done
+
+	assert.NoError(t, ctx.Err(), "the goroutine context should be detached from the parent")
+	assert.Equal(t, GetAll(parent), GetAll(ctx))
+}
+
+func TestGoRecoversPanics(t *testing.T) {
+	testLog := newTestLogger(t)
+	t.Cleanup(zap.ReplaceGlobals(testLog.GetZapLogger()))
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	done := make(chan struct{})
+	Go(ctx, func(context.Context) {
+		defer close(done)
+		panic("boom")
+	})
+	<-done
+
+	assert.Eventually(t, func() bool {
+		return len(testLog.GetRecordedLogs()) == 1
+	}, time.Second, time.Millisecond)
+	fields := testLog.GetRecordedLogs()[0].ContextMap()
+	assert.Equal(t, "boom", fields["panic"])
+	assert.Equal(t, testTraceID, fields[traceIDKey])
+	assert.Contains(t, fields, "stack")
+}
+
+func TestGoRecoversPanicsWithDefaultLogger(t *testing.T) {
+	testLog := newTestLogger(t)
+	t.Cleanup(SetDefaultLogger(testLog.GetZapLogger()))
+
+	done := make(chan struct{})
+	Go(context.Background(), func(context.Context) {
+		defer close(done)
+		panic("boom")
+	})
+	<-done
+
+	assert.Eventually(t, func() bool {
+		return len(testLog.GetRecordedLogs()) == 1
+	}, time.Second, time.Millisecond)
+}
+
+// syncBuffer is a buffer safe for concurrent use.
+type syncBuffer struct {
+	mu  sync.Mutex
+	buf bytes.Buffer
+}
+
+func (b *syncBuffer) Write(p []byte) (int, error) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.Write(p)
+}
+
+func (b *syncBuffer) String() string {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return b.buf.String()
+}
+
+func TestGoRecoversPanicsWithoutLogger(t *testing.T) {
+	t.Cleanup(SetDefaultLogger(nil))
+	var output syncBuffer
+	prev := panicOutput
+	panicOutput = &output
+	t.Cleanup(func() { panicOutput = prev })
+
+	Go(context.Background(), func(context.Context) {
+		panic("boom")
+	})
+
+	assert.Eventually(t, func() bool {
+		return strings.Contains(output.String(), "goroutine")
+	}, time.Second, time.Millisecond)
+	assert.True(t, strings.HasPrefix(output.String(), "zax: recovered from panic in goroutine: boom\n"))
+	assert.Contains(t, output.String(), "runtime/debug.Stack")
+}
@@ -0,0 +1,58 @@
+package zax
+
+import (
+	"bytes"
+	"runtime"
+	"strconv"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// GoroutineIDKey is the key of the field added by [WithGoroutineID].
+const GoroutineIDKey = "goroutine_id"
+
+// WithGoroutineID returns a logger option adding the ID of the logging
+// goroutine to every entry, to untangle interleaved concurrent logs.
+//
+// It is meant for local debugging only: finding the ID parses a stack trace
+// on every entry, and Go deliberately keeps goroutines anonymous.
+func WithGoroutineID() zap.Option {
+	return zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &goroutineIDCore{Core: core}
+	})
+}
+
+type goroutineIDCore struct {
+	zapcore.Core
+}
+
+func (c *goroutineIDCore) With(fields []zapcore.Field) zapcore.Core {
+	return &goroutineIDCore{Core: c.Core.With(fields)}
+}
+
+func (c *goroutineIDCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	return checkThrough(c.Core, ent, ent, ce, addGoroutineID)
+}
+
+// addGoroutineID returns fields followed by the ID of the calling goroutine.
+func addGoroutineID(_ zapcore.Entry, fields []zapcore.Field) []zapcore.Field {
+	return append(fields[:len(fields):len(fields)], zap.Uint64(GoroutineIDKey, goroutineID()))
+}
+
+var goroutinePrefix = []byte("goroutine ")
+
+// goroutineID returns the ID of the calling goroutine, parsed from the header
+// of its stack trace, or 0 if it can't be parsed.
+func goroutineID() uint64 {
+	var buf [64]byte
+	b := buf[:runtime.Stack(buf[:], false)]
+	b = bytes.TrimPrefix(b, goroutinePrefix)
+	if i := bytes.IndexByte(b, ' '); i > 0 {
+		b = b[:i]
+	}
+	id, _ := strconv.ParseUint(string(b), 10, 64)
+	return id
+}
+
+var _ zapcore.Core = (*goroutineIDCore)(nil)
@@ -0,0 +1,43 @@
+package zax
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestWithGoroutineID(t *testing.T) {
+	logs := newTestLogger(t)
+	logger := logs.GetZapLogger().WithOptions(WithGoroutineID())
+
+	logger.Info("main")
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		logger.Info("spawned")
+	}()
+	wg.Wait()
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	main, spawned := entries[0].ContextMap()[GoroutineIDKey], entries[1].ContextMap()[GoroutineIDKey]
+	assert.Equal(t, goroutineID(), main)
+	assert.NotZero(t, spawned)
+	assert.NotEqual(t, main, spawned)
+}
+
+func TestWithGoroutineIDKeepsSampling(t *testing.T) {
+	sampled, logs := newSampledLogger(zapcore.InfoLevel)
+	logger := sampled.WithOptions(WithGoroutineID())
+
+	for i := 0; i < 5; i++ {
+		logger.Info("repeated")
+	}
+
+	require.Equal(t, 1, logs.Len())
+	assert.Contains(t, logs.All()[0].ContextMap(), GoroutineIDKey)
+}
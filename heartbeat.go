@@ -0,0 +1,45 @@
+package zax
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// Heartbeat logs msg through logger every interval, with the fields stored in
+// ctx and the time elapsed since the call, until ctx is done or the returned
+// stop function is called. Long-running jobs use it so that their silence
+// can be told apart from a hang:
+//
+//	stop := zax.Heartbeat(ctx, logger, time.Minute, "still importing")
+//	defer stop()
+func Heartbeat(ctx context.Context, logger *zap.Logger, interval time.Duration, msg string) (stop func()) {
+	return defaultStore.Heartbeat(ctx, logger, interval, msg)
+}
+
+// Heartbeat logs msg with the fields of s in ctx every interval, as
+// measured by the clock of s; see [Heartbeat] and [WithClock].
+func (s *Store) Heartbeat(ctx context.Context, logger *zap.Logger, interval time.Duration, msg string) (stop func()) {
+	logger = s.Logger(ctx, logger)
+	clock := s.loadConfig().now()
+	start := clock.Now()
+	ticker := clock.NewTicker(interval)
+	stopped := make(chan struct{})
+	var once sync.Once
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-stopped:
+				return
+			case now := <-ticker.C:
+				logger.Info(msg, zap.Duration("elapsed", now.Sub(start)))
+			}
+		}
+	}()
+	return func() { once.Do(func() { close(stopped) }) }
+}
@@ -0,0 +1,42 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestHeartbeat(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	stop := Heartbeat(ctx, logs.GetZapLogger(), time.Millisecond, "still working")
+	assert.Eventually(t, func() bool { return len(logs.GetRecordedLogs()) >= 2 }, time.Second, time.Millisecond)
+	stop()
+	stop()
+
+	entry := logs.GetRecordedLogs()[0]
+	assert.Equal(t, "still working", entry.Message)
+	assert.Equal(t, testTraceID, entry.ContextMap()[traceIDKey])
+	assert.Contains(t, entry.ContextMap(), "elapsed")
+
+	time.Sleep(5 * time.Millisecond)
+	count := len(logs.GetRecordedLogs())
+	time.Sleep(5 * time.Millisecond)
+	assert.Len(t, logs.GetRecordedLogs(), count, "no heartbeat after stop")
+}
+
+func TestHeartbeatContextDone(t *testing.T) {
+	logs := newTestLogger(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	defer Heartbeat(ctx, logs.GetZapLogger(), time.Millisecond, "still working")()
+	time.Sleep(10 * time.Millisecond)
+	assert.Empty(t, logs.GetRecordedLogs())
+}
@@ -0,0 +1,51 @@
+package zax
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+const (
+	traceIDKey  = "trace_id"
+	spanIDKey   = "span_id"
+	testTraceID = "test-trace-id-3333"
+)
+
+// testLogger records the entries logged through it, like zaxtest.Logger,
+// which the tests of the package can't use since zaxtest imports it.
+type testLogger struct {
+	*observer.ObservedLogs
+	logger *zap.Logger
+}
+
+func newTestLogger(t testing.TB) *testLogger {
+	t.Helper()
+	core, recorded := observer.New(zapcore.DebugLevel)
+	return &testLogger{ObservedLogs: recorded, logger: zap.New(core)}
+}
+
+func (l *testLogger) GetZapLogger() *zap.Logger {
+	return l.logger
+}
+
+func (l *testLogger) GetRecordedLogs() []observer.LoggedEntry {
+	return l.All()
+}
+
+// AssertLogEntryExist asserts that some entry has a string field with key
+// and value. An empty key and value always match.
+func (l *testLogger) AssertLogEntryExist(t assert.TestingT, key, value string) bool {
+	if key == "" && value == "" {
+		return true
+	}
+	return assert.NotZero(t, l.FilterField(zap.String(key, value)).Len(), "no log entry with %s = %s", key, value)
+}
+
+// AssertLogEntryKeyExist asserts that some entry has a field with key.
+func (l *testLogger) AssertLogEntryKeyExist(t assert.TestingT, key string) bool {
+	return assert.NotZero(t, l.FilterFieldKey(key).Len(), "no log entry with key %s", key)
+}
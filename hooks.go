@@ -0,0 +1,68 @@
+package zax
+
+import (
+	"context"
+	"reflect"
+	"runtime"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// WriteEvent describes fields about to be written to a context.
+type WriteEvent struct {
+	// Fields are the fields being written.
+	Fields []zap.Field
+	// Caller is the frame that called into zax to write the fields.
+	Caller runtime.Frame
+}
+
+// Keys returns the keys of the fields being written.
+func (e WriteEvent) Keys() []string {
+	keys := make([]string, len(e.Fields))
+	for i, field := range e.Fields {
+		keys[i] = field.Key
+	}
+	return keys
+}
+
+// Hook is called whenever fields are written to ctx. It enables cross-cutting
+// policies, like auditing which components add which fields. Hooks run
+// synchronously on the writing goroutine; a hook may panic to forbid a write,
+// e.g. past a freeze point.
+type Hook func(ctx context.Context, event WriteEvent)
+
+// OnSet registers hook to be called by every subsequent Set.
+func OnSet(hook Hook) {
+	Configure(WithSetHook(hook))
+}
+
+// OnAppend registers hook to be called by every subsequent Append.
+func OnAppend(hook Hook) {
+	Configure(WithAppendHook(hook))
+}
+
+func runHooks(ctx context.Context, hooks []Hook, fields []zap.Field) {
+	if len(hooks) == 0 {
+		return
+	}
+	event := WriteEvent{Fields: fields, Caller: callerFrame()}
+	for _, hook := range hooks {
+		hook(ctx, event)
+	}
+}
+
+// packagePrefix prefixes the names of the functions of this package.
+var packagePrefix = reflect.TypeOf((*container)(nil)).Elem().PkgPath() + "."
+
+// callerFrame returns the first frame outside of this package, tests aside.
+func callerFrame() runtime.Frame {
+	var pcs [32]uintptr
+	frames := runtime.CallersFrames(pcs[:runtime.Callers(2, pcs[:])])
+	for {
+		frame, more := frames.Next()
+		if !strings.HasPrefix(frame.Function, packagePrefix) || strings.HasSuffix(frame.File, "_test.go") || !more {
+			return frame
+		}
+	}
+}
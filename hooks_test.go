@@ -0,0 +1,67 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func resetHooks(t *testing.T) {
+	t.Cleanup(func() {
+		defaultStore.updateConfig(func(c *config) {
+			c.setHooks = nil
+			c.appendHooks = nil
+		})
+	})
+}
+
+func TestHooks(t *testing.T) {
+	resetHooks(t)
+
+	var sets, appends []WriteEvent
+	OnSet(func(_ context.Context, event WriteEvent) { sets = append(sets, event) })
+	OnAppend(func(_ context.Context, event WriteEvent) { appends = append(appends, event) })
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	Append(ctx, []zap.Field{zap.String(spanIDKey, "span"), zap.Int("attempt", 1)})
+
+	if assert.Len(t, sets, 1) {
+		assert.Equal(t, []string{traceIDKey}, sets[0].Keys())
+		assert.Equal(t, "github.com/yuseferi/zax/v2.TestHooks", sets[0].Caller.Function)
+	}
+	if assert.Len(t, appends, 1) {
+		assert.Equal(t, []string{spanIDKey, "attempt"}, appends[0].Keys())
+		assert.Contains(t, appends[0].Caller.File, "hooks_test.go")
+	}
+}
+
+func TestHooksSkipPackageFrames(t *testing.T) {
+	resetHooks(t)
+
+	var caller string
+	OnAppend(func(_ context.Context, event WriteEvent) { caller = event.Caller.Function })
+
+	Extract(context.Background(), MapCarrier{CarrierPrefix + traceIDKey: testTraceID})
+	assert.Equal(t, "github.com/yuseferi/zax/v2.TestHooksSkipPackageFrames", caller)
+}
+
+func TestHookForbidsWrites(t *testing.T) {
+	resetHooks(t)
+
+	frozen := false
+	OnAppend(func(context.Context, WriteEvent) {
+		if frozen {
+			panic("fields are frozen")
+		}
+	})
+
+	ctx := Append(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	frozen = true
+	assert.PanicsWithValue(t, "fields are frozen", func() {
+		Append(ctx, []zap.Field{zap.String(spanIDKey, "span")})
+	})
+}
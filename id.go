@@ -0,0 +1,84 @@
+package zax
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"time"
+
+	"go.uber.org/zap"
+)
+
+// IDGenerator returns a new unique ID.
+type IDGenerator func() string
+
+// EnsureID returns ctx with a field with key holding an ID generated by gen,
+// unless ctx already has a field with key, along with the ID. A nil gen
+// defaults to [UUIDv7]. It covers the common "create a request ID if the
+// header was missing" logic:
+//
+//	ctx = zax.Extract(r.Context(), zax.HeaderCarrier(r.Header))
+//	ctx, id := zax.EnsureID(ctx, "request_id", nil)
+func EnsureID(ctx context.Context, key string, gen IDGenerator) (context.Context, string) {
+	return defaultStore.EnsureID(ctx, key, gen)
+}
+
+// EnsureID returns ctx with an ID field of s with key; see [EnsureID].
+func (s *Store) EnsureID(ctx context.Context, key string, gen IDGenerator) (context.Context, string) {
+	if field, ok := s.GetField(ctx, key); ok {
+		return ctx, textValue(field)
+	}
+	if gen == nil {
+		gen = UUIDv7
+	}
+	id := gen()
+	return s.Append(ctx, []zap.Field{zap.String(key, id)}), id
+}
+
+// UUIDv7 returns a random, time-ordered UUID (RFC 9562, version 7), so that
+// IDs sort by creation time.
+func UUIDv7() string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[6:])
+	ms := uint64(time.Now().UnixMilli())
+	binary.BigEndian.PutUint16(uuid[0:], uint16(ms>>32))
+	binary.BigEndian.PutUint32(uuid[2:], uint32(ms))
+	uuid[6] = uuid[6]&0x0f | 0x70
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return formatUUID(uuid)
+}
+
+// UUIDv4 returns a random UUID (RFC 9562, version 4).
+func UUIDv4() string {
+	var uuid [16]byte
+	_, _ = rand.Read(uuid[:])
+	uuid[6] = uuid[6]&0x0f | 0x40
+	uuid[8] = uuid[8]&0x3f | 0x80
+	return formatUUID(uuid)
+}
+
+// RandomHex returns a generator of IDs of n random bytes, hex-encoded, such as
+// the 16-byte trace IDs and 8-byte span IDs of W3C Trace Context.
+func RandomHex(n int) IDGenerator {
+	return func() string {
+		b := make([]byte, n)
+		_, _ = rand.Read(b)
+		return hex.EncodeToString(b)
+	}
+}
+
+// formatUUID renders uuid in its canonical, hyphenated form.
+func formatUUID(uuid [16]byte) string {
+	var buf [36]byte
+	hex.Encode(buf[0:8], uuid[0:4])
+	buf[8] = '-'
+	hex.Encode(buf[9:13], uuid[4:6])
+	buf[13] = '-'
+	hex.Encode(buf[14:18], uuid[6:8])
+	buf[18] = '-'
+	hex.Encode(buf[19:23], uuid[8:10])
+	buf[23] = '-'
+	hex.Encode(buf[24:], uuid[10:])
+	return string(buf[:])
+}
@@ -0,0 +1,56 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestEnsureID(t *testing.T) {
+	ctx, id := EnsureID(context.Background(), "request_id", func() string { return "generated" })
+	assert.Equal(t, "generated", id)
+	assert.Equal(t, []zap.Field{zap.String("request_id", "generated")}, GetAll(ctx))
+
+	same, id := EnsureID(ctx, "request_id", func() string {
+		t.Fatal("unexpected generation")
+		return ""
+	})
+	assert.Equal(t, "generated", id)
+	assert.Equal(t, ctx, same)
+
+	_, id = EnsureID(Set(context.Background(), []zap.Field{zap.Int("request_id", 42)}), "request_id", nil)
+	assert.Equal(t, "42", id)
+
+	_, id = EnsureID(context.Background(), "request_id", nil)
+	assert.Regexp(t, uuidPattern("7"), id)
+}
+
+func uuidPattern(version string) *regexp.Regexp {
+	return regexp.MustCompile(`^[0-9a-f]{8}-[0-9a-f]{4}-` + version + `[0-9a-f]{3}-[89ab][0-9a-f]{3}-[0-9a-f]{12}$`)
+}
+
+func TestUUIDv7(t *testing.T) {
+	first := UUIDv7()
+	time.Sleep(2 * time.Millisecond)
+	second := UUIDv7()
+
+	assert.Regexp(t, uuidPattern("7"), first)
+	assert.Less(t, first, second, "UUIDv7s sort by creation time")
+	assert.NotEqual(t, UUIDv7(), UUIDv7())
+}
+
+func TestUUIDv4(t *testing.T) {
+	assert.Regexp(t, uuidPattern("4"), UUIDv4())
+	assert.NotEqual(t, UUIDv4(), UUIDv4())
+}
+
+func TestRandomHex(t *testing.T) {
+	assert.Regexp(t, `^[0-9a-f]{32}$`, RandomHex(16)())
+	assert.Regexp(t, `^[0-9a-f]{16}$`, RandomHex(8)())
+}
@@ -0,0 +1,60 @@
+package zax
+
+import (
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// maxInterned bounds the intern table, so that high-cardinality values can't
+// grow it forever. Once full, new strings are simply stored as they are.
+const maxInterned = 1 << 14
+
+var (
+	interned      sync.Map
+	internedCount atomic.Int64
+)
+
+// SetInterning enables or disables interning of field keys and string values
+// stored by Set and Append. When enabled, equal strings stored across many
+// contexts (e.g. a trace ID attached to every context of a request) share
+// a single copy, reducing heap pressure in high-throughput services.
+// It is disabled by default.
+func SetInterning(enabled bool) {
+	Configure(WithInterning(enabled))
+}
+
+// intern returns the canonical copy of s.
+func intern(s string) string {
+	if canonical, ok := interned.Load(s); ok {
+		return canonical.(string)
+	}
+	if internedCount.Load() >= maxInterned {
+		return s
+	}
+	// Clone so the table never pins a larger buffer s happens to point into.
+	canonical, loaded := interned.LoadOrStore(s, strings.Clone(s))
+	if !loaded {
+		internedCount.Add(1)
+	}
+	return canonical.(string)
+}
+
+// internFields returns a copy of fields with interned keys and string values.
+func internFields(fields []zap.Field) []zap.Field {
+	if len(fields) == 0 {
+		return fields
+	}
+	canonical := make([]zap.Field, len(fields))
+	for i, field := range fields {
+		field.Key = intern(field.Key)
+		if field.Type == zapcore.StringType {
+			field.String = intern(field.String)
+		}
+		canonical[i] = field
+	}
+	return canonical
+}
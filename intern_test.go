@@ -0,0 +1,36 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"unsafe"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestInterning(t *testing.T) {
+	SetInterning(true)
+	t.Cleanup(func() { SetInterning(false) })
+
+	// Build the values at runtime so they don't share constant storage.
+	first := string([]byte(testTraceID))
+	second := string([]byte(testTraceID))
+
+	ctx1 := Set(context.Background(), []zap.Field{zap.String(traceIDKey, first)})
+	ctx2 := Append(context.Background(), []zap.Field{zap.String(traceIDKey, second)})
+
+	field1, _ := GetField(ctx1, traceIDKey)
+	field2, _ := GetField(ctx2, traceIDKey)
+	assert.Equal(t, testTraceID, field2.String)
+	assert.Equal(t, unsafe.StringData(field1.String), unsafe.StringData(field2.String))
+}
+
+func TestInterningDisabled(t *testing.T) {
+	fields := []zap.Field{zap.String(traceIDKey, string([]byte(testTraceID)))}
+	ctx := Set(context.Background(), fields)
+	field, _ := GetField(ctx, traceIDKey)
+	assert.Equal(t, unsafe.StringData(fields[0].String), unsafe.StringData(field.String))
+}
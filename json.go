@@ -0,0 +1,185 @@
+package zax
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// jsonField is the serialized form of a single zap field.
+type jsonField struct {
+	Key   string          `json:"key"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value"`
+}
+
+// jsonCodec converts the value of one zapcore field type to and from JSON.
+type jsonCodec struct {
+	name   string
+	encode func(zap.Field) any
+	decode func(key string, raw json.RawMessage) (zap.Field, error)
+}
+
+const anyJSONType = "any"
+
+var (
+	jsonCodecs = map[zapcore.FieldType]jsonCodec{
+		zapcore.StringType:     {"string", stringValue, decodeWith(zap.String)},
+		zapcore.StringerType:   {"string", stringerValue, nil},
+		zapcore.ByteStringType: {"bytestring", byteStringValue, decodeWith(byteString)},
+		zapcore.BinaryType:     {"binary", interfaceValue, decodeWith(zap.Binary)},
+		zapcore.BoolType:       {"bool", boolValue, decodeWith(zap.Bool)},
+		zapcore.Int64Type:      {"int64", intValue, decodeWith(zap.Int64)},
+		zapcore.Int32Type:      {"int32", intValue, decodeWith(zap.Int32)},
+		zapcore.Int16Type:      {"int16", intValue, decodeWith(zap.Int16)},
+		zapcore.Int8Type:       {"int8", intValue, decodeWith(zap.Int8)},
+		zapcore.Uint64Type:     {"uint64", uintValue, decodeWith(zap.Uint64)},
+		zapcore.Uint32Type:     {"uint32", uintValue, decodeWith(zap.Uint32)},
+		zapcore.Uint16Type:     {"uint16", uintValue, decodeWith(zap.Uint16)},
+		zapcore.Uint8Type:      {"uint8", uintValue, decodeWith(zap.Uint8)},
+		zapcore.UintptrType:    {"uintptr", uintValue, decodeWith(zap.Uintptr)},
+		zapcore.Float64Type:    {"float64", float64Value, decodeWith(zap.Float64)},
+		zapcore.Float32Type:    {"float32", float32Value, decodeWith(zap.Float32)},
+		zapcore.DurationType:   {"duration", durationValue, decodeWith(zap.Duration)},
+		zapcore.TimeType:       {"time", timeValue, decodeWith(zap.Time)},
+		zapcore.TimeFullType:   {"time", interfaceValue, nil},
+		zapcore.ErrorType:      {"error", errorValue, decodeWith(namedError)},
+	}
+	anyCodec = jsonCodec{anyJSONType, EncodedValue, decodeWith(zap.Any)}
+
+	jsonDecoders = func() map[string]jsonCodec {
+		decoders := map[string]jsonCodec{anyJSONType: anyCodec}
+		for _, codec := range jsonCodecs {
+			if codec.decode != nil {
+				decoders[codec.name] = codec
+			}
+		}
+		return decoders
+	}()
+)
+
+// MarshalJSON serializes the fields stored in ctx, so they can be persisted
+// (e.g. along with a job) or passed through systems that only carry strings.
+// Common zapcore field types round-trip with their type; other fields, such as
+// arrays and objects, are stored as their JSON representation.
+func MarshalJSON(ctx context.Context) ([]byte, error) {
+	return defaultStore.EncodeJSON(ctx)
+}
+
+// EncodeJSON serializes the fields of the store in ctx; see [MarshalJSON].
+func (s *Store) EncodeJSON(ctx context.Context) ([]byte, error) {
+	return marshalFields(s.rawFields(ctx))
+}
+
+// UnmarshalJSON appends the fields serialized by [MarshalJSON] to ctx.
+func UnmarshalJSON(ctx context.Context, data []byte) (context.Context, error) {
+	return defaultStore.DecodeJSON(ctx, data)
+}
+
+// DecodeJSON appends the fields serialized by [Store.EncodeJSON] to the store
+// in ctx; see [UnmarshalJSON].
+func (s *Store) DecodeJSON(ctx context.Context, data []byte) (context.Context, error) {
+	fields, err := unmarshalFields(data)
+	if err != nil {
+		return ctx, err
+	}
+	return s.Append(ctx, fields), nil
+}
+
+func marshalFields(fields []zap.Field) ([]byte, error) {
+	serialized := make([]jsonField, 0, len(fields))
+	for _, field := range fields {
+		if field.Type == zapcore.SkipType {
+			continue
+		}
+		codec, ok := jsonCodecs[field.Type]
+		if !ok {
+			codec = anyCodec
+		}
+		value, err := json.Marshal(codec.encode(field))
+		if err != nil {
+			return nil, fmt.Errorf("zax: marshal field %q: %w", field.Key, err)
+		}
+		serialized = append(serialized, jsonField{Key: field.Key, Type: codec.name, Value: value})
+	}
+	return json.Marshal(serialized)
+}
+
+func unmarshalFields(data []byte) ([]zap.Field, error) {
+	var serialized []jsonField
+	if err := json.Unmarshal(data, &serialized); err != nil {
+		return nil, fmt.Errorf("zax: unmarshal fields: %w", err)
+	}
+	fields := make([]zap.Field, 0, len(serialized))
+	for _, s := range serialized {
+		codec, ok := jsonDecoders[s.Type]
+		if !ok {
+			return nil, fmt.Errorf("zax: unmarshal field %q: unknown type %q", s.Key, s.Type)
+		}
+		field, err := codec.decode(s.Key, s.Value)
+		if err != nil {
+			return nil, fmt.Errorf("zax: unmarshal field %q: %w", s.Key, err)
+		}
+		fields = append(fields, field)
+	}
+	return fields, nil
+}
+
+func decodeWith[T any](build func(string, T) zap.Field) func(string, json.RawMessage) (zap.Field, error) {
+	return func(key string, raw json.RawMessage) (zap.Field, error) {
+		var value T
+		if err := json.Unmarshal(raw, &value); err != nil {
+			return zap.Field{}, err
+		}
+		return build(key, value), nil
+	}
+}
+
+func byteString(key string, value string) zap.Field {
+	return zap.ByteString(key, []byte(value))
+}
+
+func namedError(key string, message string) zap.Field {
+	return zap.NamedError(key, errors.New(message))
+}
+
+func stringValue(field zap.Field) any    { return field.String }
+func interfaceValue(field zap.Field) any { return field.Interface }
+func boolValue(field zap.Field) any      { return field.Integer == 1 }
+func intValue(field zap.Field) any       { return field.Integer }
+func uintValue(field zap.Field) any      { return uint64(field.Integer) }
+func durationValue(field zap.Field) any  { return time.Duration(field.Integer) }
+
+func float64Value(field zap.Field) any {
+	return math.Float64frombits(uint64(field.Integer))
+}
+
+func float32Value(field zap.Field) any {
+	return math.Float32frombits(uint32(field.Integer))
+}
+
+func stringerValue(field zap.Field) any {
+	return field.Interface.(fmt.Stringer).String()
+}
+
+func byteStringValue(field zap.Field) any {
+	return string(field.Interface.([]byte))
+}
+
+func errorValue(field zap.Field) any {
+	return field.Interface.(error).Error()
+}
+
+func timeValue(field zap.Field) any {
+	t := time.Unix(0, field.Integer)
+	if loc, ok := field.Interface.(*time.Location); ok {
+		t = t.In(loc)
+	}
+	return t
+}
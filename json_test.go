@@ -0,0 +1,88 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestJSONRoundTrip(t *testing.T) {
+	now := time.Date(2024, 5, 1, 12, 30, 0, 42, time.UTC)
+	fields := []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Bool("bool", true),
+		zap.Int("int", -42),
+		zap.Int32("int32", 32),
+		zap.Uint8("uint8", 8),
+		zap.Float64("float64", 1.5),
+		zap.Float32("float32", 0.25),
+		zap.Duration("duration", 3*time.Second),
+		zap.Time("time", now),
+		zap.Binary("binary", []byte{0, 1, 2}),
+		zap.ByteString("bytestring", []byte("bytes")),
+		zap.NamedError("err", errors.New("boom")),
+	}
+	ctx := Set(context.Background(), fields)
+
+	data, err := MarshalJSON(ctx)
+	require.NoError(t, err)
+
+	restored, err := UnmarshalJSON(context.Background(), data)
+	require.NoError(t, err)
+
+	got := GetAll(restored)
+	require.Len(t, got, len(fields))
+	for i, field := range fields {
+		assert.Equal(t, field.Key, got[i].Key)
+		assert.Equal(t, field.Type, got[i].Type, field.Key)
+	}
+	assert.Equal(t, zap.String(traceIDKey, testTraceID), got[0])
+	assert.Equal(t, zap.Float64("float64", 1.5), got[5])
+	assert.Equal(t, zap.Duration("duration", 3*time.Second), got[7])
+	assert.True(t, now.Equal(time.Unix(0, got[8].Integer)))
+	assert.Equal(t, []byte{0, 1, 2}, got[9].Interface)
+	assert.EqualError(t, got[11].Interface.(error), "boom")
+}
+
+func TestJSONFallsBackToEncodedValue(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Stringer("stringer", time.Second),
+	})
+
+	data, err := MarshalJSON(ctx)
+	require.NoError(t, err)
+
+	restored, err := UnmarshalJSON(context.Background(), data)
+	require.NoError(t, err)
+
+	tags, ok := GetField(restored, "tags")
+	require.True(t, ok)
+	assert.Equal(t, []any{"a", "b"}, tags.Interface)
+
+	stringer, ok := GetField(restored, "stringer")
+	require.True(t, ok)
+	assert.Equal(t, zap.String("stringer", "1s"), stringer)
+	assert.Equal(t, zapcore.StringType, stringer.Type)
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	ctx := context.Background()
+
+	_, err := UnmarshalJSON(ctx, []byte("not json"))
+	assert.Error(t, err)
+
+	_, err = UnmarshalJSON(ctx, []byte(`[{"key":"k","type":"unknown","value":1}]`))
+	assert.ErrorContains(t, err, `unknown type "unknown"`)
+
+	_, err = UnmarshalJSON(ctx, []byte(`[{"key":"k","type":"int64","value":"x"}]`))
+	assert.ErrorContains(t, err, `field "k"`)
+}
@@ -0,0 +1,129 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Well-known keys, standardizing the names of common correlation fields
+// across services.
+var (
+	TraceIDKey   = StringKey("trace_id")
+	SpanIDKey    = StringKey("span_id")
+	RequestIDKey = StringKey("request_id")
+	UserIDKey    = StringKey("user_id")
+	TenantIDKey  = StringKey("tenant_id")
+)
+
+// Key is a typed field key, giving typed access to the fields stored with its
+// name. Declare keys once, for example in a package shared by services, to
+// keep their names and types consistent:
+//
+//	var OrderIDKey = zax.StringKey("order_id")
+type Key[T any] struct {
+	name  string
+	field func(string, T) zap.Field
+	value func(zap.Field) (T, bool)
+}
+
+// NewKey returns a key named name, whose values are written as fields by
+// field, and read from fields by value.
+func NewKey[T any](name string, field func(string, T) zap.Field, value func(zap.Field) (T, bool)) Key[T] {
+	return Key[T]{name: name, field: field, value: value}
+}
+
+// StringKey returns a key named name with string values.
+func StringKey(name string) Key[string] {
+	return NewKey(name, zap.String, func(field zap.Field) (string, bool) {
+		return field.String, field.Type == zapcore.StringType
+	})
+}
+
+// Int64Key returns a key named name with int64 values.
+func Int64Key(name string) Key[int64] {
+	return NewKey(name, zap.Int64, func(field zap.Field) (int64, bool) {
+		return field.Integer, field.Type == zapcore.Int64Type
+	})
+}
+
+// Name returns the name of k.
+func (k Key[T]) Name() string {
+	return k.name
+}
+
+// Field returns a field with k holding value.
+func (k Key[T]) Field(value T) zap.Field {
+	return k.field(k.name, value)
+}
+
+// Set returns ctx with value appended for k.
+func (k Key[T]) Set(ctx context.Context, value T) context.Context {
+	return Append(ctx, []zap.Field{k.Field(value)})
+}
+
+// Get returns the value stored in ctx for k, if any. It reports false if the
+// field has another type.
+func (k Key[T]) Get(ctx context.Context) (value T, ok bool) {
+	field, ok := GetField(ctx, k.name)
+	if !ok {
+		return value, false
+	}
+	return k.value(field)
+}
+
+// SetTraceID returns ctx with the trace ID id; see [TraceIDKey].
+func SetTraceID(ctx context.Context, id string) context.Context {
+	return TraceIDKey.Set(ctx, id)
+}
+
+// TraceID returns the trace ID in ctx, or "" if there is none.
+func TraceID(ctx context.Context) string {
+	id, _ := TraceIDKey.Get(ctx)
+	return id
+}
+
+// SetSpanID returns ctx with the span ID id; see [SpanIDKey].
+func SetSpanID(ctx context.Context, id string) context.Context {
+	return SpanIDKey.Set(ctx, id)
+}
+
+// SpanID returns the span ID in ctx, or "" if there is none.
+func SpanID(ctx context.Context) string {
+	id, _ := SpanIDKey.Get(ctx)
+	return id
+}
+
+// SetRequestID returns ctx with the request ID id; see [RequestIDKey].
+func SetRequestID(ctx context.Context, id string) context.Context {
+	return RequestIDKey.Set(ctx, id)
+}
+
+// RequestID returns the request ID in ctx, or "" if there is none.
+func RequestID(ctx context.Context) string {
+	id, _ := RequestIDKey.Get(ctx)
+	return id
+}
+
+// SetUserID returns ctx with the user ID id; see [UserIDKey].
+func SetUserID(ctx context.Context, id string) context.Context {
+	return UserIDKey.Set(ctx, id)
+}
+
+// UserID returns the user ID in ctx, or "" if there is none.
+func UserID(ctx context.Context) string {
+	id, _ := UserIDKey.Get(ctx)
+	return id
+}
+
+// SetTenant returns ctx with the tenant ID id; see [TenantIDKey].
+func SetTenant(ctx context.Context, id string) context.Context {
+	return TenantIDKey.Set(ctx, id)
+}
+
+// Tenant returns the tenant ID in ctx, or "" if there is none.
+func Tenant(ctx context.Context) string {
+	id, _ := TenantIDKey.Get(ctx)
+	return id
+}
@@ -0,0 +1,42 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestKey(t *testing.T) {
+	attemptKey := Int64Key("attempt")
+	ctx := attemptKey.Set(context.Background(), 3)
+
+	attempt, ok := attemptKey.Get(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), attempt)
+	assert.Equal(t, []zap.Field{zap.Int64("attempt", 3)}, GetAll(ctx))
+
+	_, ok = attemptKey.Get(context.Background())
+	assert.False(t, ok)
+	_, ok = attemptKey.Get(Set(context.Background(), []zap.Field{zap.String("attempt", "three")}))
+	assert.False(t, ok, "fields of another type are not returned")
+}
+
+func TestWellKnownKeys(t *testing.T) {
+	ctx := SetTraceID(context.Background(), "trace")
+	ctx = SetSpanID(ctx, "span")
+	ctx = SetRequestID(ctx, "request")
+	ctx = SetUserID(ctx, "alice")
+	ctx = SetTenant(ctx, "acme")
+
+	assert.Equal(t, "trace", TraceID(ctx))
+	assert.Equal(t, "span", SpanID(ctx))
+	assert.Equal(t, "request", RequestID(ctx))
+	assert.Equal(t, "alice", UserID(ctx))
+	assert.Equal(t, "acme", Tenant(ctx))
+	assert.Equal(t, zap.String("tenant_id", "acme"), GetAll(ctx)[0])
+	assert.Empty(t, Tenant(context.Background()))
+}
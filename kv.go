@@ -0,0 +1,59 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// BadKey is the key used for values passed to [SetKV] or [AppendKV] without
+// a proper string key.
+const BadKey = "!BADKEY"
+
+// SetKV is like [Set], but takes loosely-typed key-value pairs, in the style
+// of [zap.SugaredLogger.With]:
+//
+//	ctx = zax.SetKV(ctx, "trace_id", traceID, "attempt", 3)
+//
+// Values are converted with [zap.Any]. A [zap.Field] may be passed in place of
+// a pair. A value whose key isn't a string, or a trailing key without value,
+// is stored under [BadKey].
+func SetKV(ctx context.Context, keysAndValues ...any) context.Context {
+	return defaultStore.SetKV(ctx, keysAndValues...)
+}
+
+// SetKV is like [Store.Set], but takes key-value pairs; see [SetKV].
+func (s *Store) SetKV(ctx context.Context, keysAndValues ...any) context.Context {
+	return s.Set(ctx, kvFields(keysAndValues))
+}
+
+// AppendKV is like [Append], but takes loosely-typed key-value pairs; see
+// [SetKV].
+func AppendKV(ctx context.Context, keysAndValues ...any) context.Context {
+	return defaultStore.AppendKV(ctx, keysAndValues...)
+}
+
+// AppendKV is like [Store.Append], but takes key-value pairs; see [SetKV].
+func (s *Store) AppendKV(ctx context.Context, keysAndValues ...any) context.Context {
+	return s.Append(ctx, kvFields(keysAndValues))
+}
+
+func kvFields(keysAndValues []any) []zap.Field {
+	fields := make([]zap.Field, 0, len(keysAndValues)/2+1)
+	for i := 0; i < len(keysAndValues); i++ {
+		switch key := keysAndValues[i].(type) {
+		case zap.Field:
+			fields = append(fields, key)
+		case string:
+			if i == len(keysAndValues)-1 {
+				fields = append(fields, zap.String(BadKey, key))
+				break
+			}
+			i++
+			fields = append(fields, zap.Any(key, keysAndValues[i]))
+		default:
+			fields = append(fields, zap.Any(BadKey, key))
+		}
+	}
+	return fields
+}
@@ -0,0 +1,41 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSetKV(t *testing.T) {
+	ctx := SetKV(context.Background(), traceIDKey, testTraceID, "attempt", 3, zap.Duration("budget", time.Second))
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Int("attempt", 3),
+		zap.Duration("budget", time.Second),
+	}, GetAll(ctx))
+
+	ctx = AppendKV(ctx, spanIDKey, "span")
+	assert.Len(t, GetAll(ctx), 4)
+	assert.Equal(t, zap.String(spanIDKey, "span"), GetAll(ctx)[0])
+}
+
+func TestKVBadKeys(t *testing.T) {
+	assert.Equal(t, []zap.Field{
+		zap.Int(BadKey, 42),
+		zap.Bool("ok", true),
+		zap.String(BadKey, "dangling"),
+	}, kvFields([]any{42, "ok", true, "dangling"}))
+}
+
+func TestStoreKV(t *testing.T) {
+	store := NewStore()
+	ctx := store.SetKV(context.Background(), traceIDKey, testTraceID)
+	ctx = store.AppendKV(ctx, spanIDKey, "span")
+	assert.Len(t, store.GetAll(ctx), 2)
+	assert.Empty(t, GetAll(ctx))
+}
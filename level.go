@@ -0,0 +1,66 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// levelKey is the context key of the level set by [WithLevel].
+type levelKey struct{}
+
+// WithLevel returns ctx with a level override: loggers returned by [Logger]
+// for ctx and its children log entries at level and above, even if the
+// logger they derive from is set to a higher level. It lets single requests,
+// such as those flagged for debugging, be logged verbosely in production.
+func WithLevel(ctx context.Context, level zapcore.Level) context.Context {
+	return context.WithValue(ctx, levelKey{}, level)
+}
+
+// LevelFrom returns the level override of ctx set by [WithLevel], if any.
+func LevelFrom(ctx context.Context) (zapcore.Level, bool) {
+	level, ok := ctx.Value(levelKey{}).(zapcore.Level)
+	return level, ok
+}
+
+// withLevel returns logger applying the level override of ctx, if any.
+func withLevel(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	level, ok := LevelFrom(ctx)
+	if !ok {
+		return logger
+	}
+	return logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return &levelCore{Core: core, level: level}
+	}))
+}
+
+// levelCore enables entries at level and above, bypassing the level of the
+// wrapped core but not its other filtering.
+type levelCore struct {
+	zapcore.Core
+	level zapcore.Level
+}
+
+func (c *levelCore) Enabled(level zapcore.Level) bool {
+	return level >= c.level || c.Core.Enabled(level)
+}
+
+func (c *levelCore) With(fields []zapcore.Field) zapcore.Core {
+	return &levelCore{Core: c.Core.With(fields), level: c.level}
+}
+
+func (c *levelCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if ent.Level < c.level || c.Core.Enabled(ent.Level) {
+		return c.Core.Check(ent, ce)
+	}
+	// Lift the level gate of the wrapped core only, checking the entry at
+	// the lowest level it enables so that it still samples or throttles it.
+	probe := ent
+	if probe.Level = zapcore.LevelOf(c.Core); probe.Level == zapcore.InvalidLevel {
+		return ce
+	}
+	return checkThrough(c.Core, ent, probe, ce, nil)
+}
+
+var _ zapcore.Core = (*levelCore)(nil)
@@ -0,0 +1,61 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestWithLevel(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	Logger(ctx, logger).Debug("dropped")
+	debugCtx := WithLevel(ctx, zapcore.DebugLevel)
+	Logger(debugCtx, logger).Debug("kept")
+	Logger(Append(debugCtx, []zap.Field{zap.Int("step", 2)}), logger).Info("kept too")
+
+	level, ok := LevelFrom(debugCtx)
+	assert.True(t, ok)
+	assert.Equal(t, zapcore.DebugLevel, level)
+	_, ok = LevelFrom(ctx)
+	assert.False(t, ok)
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "kept", entries[0].Message)
+	assert.Equal(t, testTraceID, entries[0].ContextMap()[traceIDKey])
+	assert.Equal(t, "kept too", entries[1].Message)
+}
+
+func TestWithLevelRaisesNothing(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	ctx := WithLevel(context.Background(), zapcore.ErrorLevel)
+
+	Logger(ctx, zap.New(core)).Debug("still logged")
+	assert.Equal(t, 1, logs.Len(), "the override only lowers the level")
+}
+
+func TestWithLevelKeepsSampling(t *testing.T) {
+	logger, logs := newSampledLogger(zapcore.InfoLevel)
+	ctx := WithLevel(context.Background(), zapcore.DebugLevel)
+
+	for i := 0; i < 5; i++ {
+		Logger(ctx, logger).Debug("repeated debug")
+		Logger(ctx, logger).Info("repeated info")
+	}
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 2, "the override lifts the level only, not sampling")
+	assert.Equal(t, zapcore.DebugLevel, entries[0].Level)
+	assert.Equal(t, "repeated debug", entries[0].Message)
+	assert.Equal(t, zapcore.InfoLevel, entries[1].Level)
+}
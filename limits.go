@@ -0,0 +1,209 @@
+package zax
+
+import (
+	"unicode/utf8"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TruncatedKey is the zap field key of the marker added by [OverflowMark] when
+// fields or values had to be cut to respect the configured [Limits].
+const TruncatedKey string = "_truncated"
+
+// OverflowPolicy decides what happens to a field whose value exceeds
+// [Limits.MaxValueLength], and whether overflows are marked.
+type OverflowPolicy int
+
+const (
+	// OverflowTruncate cuts oversized values down to the maximum length.
+	OverflowTruncate OverflowPolicy = iota
+	// OverflowDrop removes fields with oversized values altogether.
+	OverflowDrop
+	// OverflowMark truncates like OverflowTruncate and additionally adds a
+	// [TruncatedKey] field whenever anything was cut.
+	OverflowMark
+)
+
+// Limits caps what a context can hold. They are enforced by Set and Append,
+// protecting against contexts that grow without bound, e.g. when fields are
+// appended in a loop. The zero value imposes no limits.
+type Limits struct {
+	// MaxFields is the maximum number of fields stored in a context,
+	// including the [TruncatedKey] marker and the original length fields.
+	// When exceeded, the oldest fields are dropped.
+	MaxFields int
+	// MaxValueLength is the maximum length, in bytes, of string and byte
+	// string values.
+	MaxValueLength int
+	// Overflow is the policy applied when a value exceeds MaxValueLength.
+	Overflow OverflowPolicy
+	// Ellipsis, such as [DefaultEllipsis], ends truncated values, which
+	// including it are at most MaxValueLength long.
+	Ellipsis string
+	// LengthSuffix, if set, makes a field with the original length of each
+	// truncated value be stored next to it, with the key of the truncated
+	// field followed by LengthSuffix.
+	LengthSuffix string
+	// MaxBytes is the budget, in bytes, of the fields stored in a context,
+	// counting their keys and the length of their string and byte string
+	// values, including the [TruncatedKey] marker and the original length
+	// fields. When exceeded, the oldest fields are dropped.
+	MaxBytes int
+}
+
+// DefaultEllipsis is the usual [Limits.Ellipsis].
+const DefaultEllipsis = "…"
+
+// otherValueSize is the size counted against [Limits.MaxBytes] for values
+// other than strings and byte strings.
+const otherValueSize = 8
+
+// SetLimits sets the limits enforced on every subsequent Set and Append.
+func SetLimits(limits Limits) {
+	Configure(WithLimits(limits))
+}
+
+// enforce returns fields cut down to l. Fields are ordered newest first, so
+// the fields beyond MaxFields or MaxBytes are the oldest ones.
+func (l Limits) enforce(fields []zap.Field) []zap.Field {
+	if l.MaxFields <= 0 && l.MaxValueLength <= 0 && l.MaxBytes <= 0 {
+		return fields
+	}
+	e := limiter{Limits: l, limited: make([]zap.Field, 0, len(fields))}
+	for _, field := range fields {
+		if !e.add(field) {
+			break
+		}
+	}
+	return e.result()
+}
+
+// limiter accumulates the fields kept by [Limits.enforce].
+type limiter struct {
+	Limits
+	limited   []zap.Field
+	size      int
+	truncated bool
+}
+
+// add keeps field, or part of it, if it fits, reporting whether older fields
+// may still fit.
+func (e *limiter) add(field zap.Field) bool {
+	if field.Key == TruncatedKey && e.Overflow == OverflowMark {
+		e.truncated = true
+		return true
+	}
+	original := valueSize(field)
+	field, cut := e.limitValue(field)
+	if cut {
+		e.truncated = true
+		if e.Overflow == OverflowDrop {
+			return true
+		}
+	}
+	if !e.fits(field) {
+		e.truncated = true
+		return false
+	}
+	e.keep(field)
+	if cut && e.LengthSuffix != "" {
+		e.keepIfFits(zap.Int(field.Key+e.LengthSuffix, original))
+	}
+	return true
+}
+
+// fits reports whether field can be kept within MaxFields and MaxBytes.
+func (e *limiter) fits(field zap.Field) bool {
+	if e.MaxFields > 0 && len(e.limited) >= e.MaxFields {
+		return false
+	}
+	return e.MaxBytes <= 0 || e.size+fieldSize(field) <= e.MaxBytes
+}
+
+func (e *limiter) keep(field zap.Field) {
+	e.limited = append(e.limited, field)
+	e.size += fieldSize(field)
+}
+
+func (e *limiter) keepIfFits(field zap.Field) {
+	if e.fits(field) {
+		e.keep(field)
+	}
+}
+
+// result returns the kept fields, followed by the [TruncatedKey] marker if
+// anything was cut and overflows are marked. The marker counts against the
+// limits, displacing the oldest fields.
+func (e *limiter) result() []zap.Field {
+	if !e.truncated || e.Overflow != OverflowMark {
+		return e.limited
+	}
+	marker := zap.Bool(TruncatedKey, true)
+	for len(e.limited) > 0 && !e.fits(marker) {
+		oldest := e.limited[len(e.limited)-1]
+		e.limited = e.limited[:len(e.limited)-1]
+		e.size -= fieldSize(oldest)
+	}
+	e.keepIfFits(marker)
+	return e.limited
+}
+
+// fieldSize returns the size of field counted against MaxBytes.
+func fieldSize(field zap.Field) int {
+	return len(field.Key) + valueSize(field)
+}
+
+// valueSize returns the size of the value of field counted against
+// MaxBytes.
+func valueSize(field zap.Field) int {
+	switch field.Type {
+	case zapcore.StringType:
+		return len(field.String)
+	case zapcore.ByteStringType:
+		if b, ok := field.Interface.([]byte); ok {
+			return len(b)
+		}
+	case zapcore.SkipType:
+		return 0
+	}
+	return otherValueSize
+}
+
+// limitValue truncates the value of field to MaxValueLength, ending it with
+// Ellipsis, reporting whether it had to.
+func (l Limits) limitValue(field zap.Field) (zap.Field, bool) {
+	if l.MaxValueLength <= 0 {
+		return field, false
+	}
+	switch field.Type {
+	case zapcore.StringType:
+		if len(field.String) > l.MaxValueLength {
+			field.String = l.truncate(field.String)
+			return field, true
+		}
+	case zapcore.ByteStringType:
+		if b, ok := field.Interface.([]byte); ok && len(b) > l.MaxValueLength {
+			field.Interface = []byte(l.truncate(string(b)))
+			return field, true
+		}
+	}
+	return field, false
+}
+
+// truncate cuts s to MaxValueLength bytes, ending it with Ellipsis if it
+// fits.
+func (l Limits) truncate(s string) string {
+	if len(l.Ellipsis) >= l.MaxValueLength {
+		return truncate(s, l.MaxValueLength)
+	}
+	return truncate(s, l.MaxValueLength-len(l.Ellipsis)) + l.Ellipsis
+}
+
+// truncate cuts s to at most n bytes without splitting a UTF-8 sequence.
+func truncate(s string, n int) string {
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
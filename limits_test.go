@@ -0,0 +1,154 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+// limitsCase is a case of the table tests of [Limits].
+type limitsCase struct {
+	limits       Limits
+	fields       []zap.Field
+	expectedKeys []string
+	expectedLong string
+}
+
+// long is the value of the field with key "a" in the limits cases.
+var long = strings.Repeat("x", 10)
+
+func runLimitsCases(t *testing.T, tests map[string]limitsCase) {
+	t.Helper()
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			SetLimits(tc.limits)
+			t.Cleanup(func() { SetLimits(Limits{}) })
+
+			ctx := Set(context.Background(), tc.fields)
+			fields := GetAll(ctx)
+			keys := make([]string, len(fields))
+			for i, field := range fields {
+				keys[i] = field.Key
+			}
+			assert.Equal(t, tc.expectedKeys, keys)
+			if field, ok := GetField(ctx, "a"); ok {
+				assert.Equal(t, tc.expectedLong, field.String)
+			}
+		})
+	}
+}
+
+func TestLimits(t *testing.T) {
+	runLimitsCases(t, map[string]limitsCase{
+		"no limits": {
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b")},
+			expectedKeys: []string{"a", "b"},
+			expectedLong: long,
+		},
+		"too many fields drops the oldest": {
+			limits:       Limits{MaxFields: 2},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b"), zap.String("c", "c")},
+			expectedKeys: []string{"a", "b"},
+			expectedLong: long,
+		},
+		"long value is truncated": {
+			limits:       Limits{MaxValueLength: 4},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b")},
+			expectedKeys: []string{"a", "b"},
+			expectedLong: "xxxx",
+		},
+		"long value is dropped": {
+			limits:       Limits{MaxValueLength: 4, Overflow: OverflowDrop},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b")},
+			expectedKeys: []string{"b"},
+		},
+		"marker counts against the maximum number of fields": {
+			limits:       Limits{MaxFields: 2, MaxValueLength: 4, Overflow: OverflowMark},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b"), zap.String("c", "c")},
+			expectedKeys: []string{"a", TruncatedKey},
+			expectedLong: "xxxx",
+		},
+	})
+}
+
+func TestLimitsTruncation(t *testing.T) {
+	runLimitsCases(t, map[string]limitsCase{
+		"truncated value ends with an ellipsis": {
+			limits:       Limits{MaxValueLength: 6, Ellipsis: DefaultEllipsis},
+			fields:       []zap.Field{zap.String("a", long)},
+			expectedKeys: []string{"a"},
+			expectedLong: "xxx" + DefaultEllipsis,
+		},
+		"ellipsis longer than the maximum length": {
+			limits:       Limits{MaxValueLength: 2, Ellipsis: DefaultEllipsis},
+			fields:       []zap.Field{zap.String("a", long)},
+			expectedKeys: []string{"a"},
+			expectedLong: "xx",
+		},
+		"original length is stored": {
+			limits:       Limits{MaxValueLength: 4, LengthSuffix: "_length"},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b")},
+			expectedKeys: []string{"a", "a_length", "b"},
+			expectedLong: "xxxx",
+		},
+		"original length counts against the maximum number of fields": {
+			limits:       Limits{MaxFields: 2, MaxValueLength: 4, LengthSuffix: "_length"},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b")},
+			expectedKeys: []string{"a", "a_length"},
+			expectedLong: "xxxx",
+		},
+	})
+}
+
+func TestLimitsBytes(t *testing.T) {
+	runLimitsCases(t, map[string]limitsCase{
+		"byte budget drops the oldest": {
+			limits:       Limits{MaxBytes: 12},
+			fields:       []zap.Field{zap.String("a", long), zap.String("b", "b"), zap.String("c", "c")},
+			expectedKeys: []string{"a"},
+			expectedLong: long,
+		},
+		"byte budget applies to truncated values and the marker": {
+			limits:       Limits{MaxValueLength: 4, MaxBytes: 24, Overflow: OverflowMark},
+			fields:       []zap.Field{zap.String("a", long), zap.Int("b", 1), zap.String("c", "c")},
+			expectedKeys: []string{"a", TruncatedKey},
+			expectedLong: "xxxx",
+		},
+	})
+}
+
+func TestLimitsOnAppend(t *testing.T) {
+	SetLimits(Limits{MaxFields: 3, Overflow: OverflowMark})
+	t.Cleanup(func() { SetLimits(Limits{}) })
+
+	ctx := context.Background()
+	for i := 0; i < 10; i++ {
+		ctx = Append(ctx, []zap.Field{zap.Int("attempt", i)})
+	}
+	fields := GetAll(ctx)
+	assert.Len(t, fields, 3)
+	assert.Equal(t, int64(9), fields[0].Integer)
+	assert.Equal(t, TruncatedKey, fields[2].Key)
+}
+
+func TestTruncateKeepsRunesWhole(t *testing.T) {
+	assert.Equal(t, "h", truncate("hé", 2))
+	assert.Equal(t, "hé", truncate("héllo", 3))
+}
+
+func TestLimitsLengthOnAppend(t *testing.T) {
+	store := NewStore(WithLimits(Limits{MaxValueLength: 4, LengthSuffix: "_length"}))
+
+	ctx := store.Set(context.Background(), []zap.Field{zap.String("a", long)})
+	ctx = store.Append(ctx, []zap.Field{zap.Int("b", 1)})
+
+	length, ok := store.GetField(ctx, "a_length")
+	assert.True(t, ok)
+	assert.Equal(t, int64(10), length.Integer)
+	assert.Len(t, store.GetAll(ctx), 3, "values truncated once aren't truncated again")
+}
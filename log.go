@@ -0,0 +1,194 @@
+package zax
+
+import (
+	"context"
+	"fmt"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Placement tells where the logging functions, such as [Info], emit the
+// fields stored in the context relative to the fields of the call site.
+type Placement int
+
+const (
+	// ContextFirst emits the fields of the context before those of the call
+	// site, like logging through [Logger] does.
+	ContextFirst Placement = iota
+	// ContextLast emits the fields of the call site before those of the
+	// context.
+	ContextLast
+)
+
+// WithPlacement sets where the logging functions emit the fields of the
+// context; the default is [ContextFirst].
+func WithPlacement(placement Placement) Option {
+	return func(c *config) {
+		c.placement = placement
+	}
+}
+
+// WithCallSiteOverride enables or disables letting the fields of the call
+// site of the logging functions override the fields of the context with the
+// same key, which are then left out of the entry instead of being emitted
+// twice. It doesn't apply to fields nested by [WithContextObject].
+func WithCallSiteOverride(enabled bool) Option {
+	return func(c *config) {
+		c.callSiteOverride = enabled
+	}
+}
+
+// Debug logs msg at debug level through logger, with the fields stored in ctx
+// and fields, placed as configured with [WithPlacement] and
+// [WithCallSiteOverride]. Like [Logger], it applies the level override,
+// sampling and deadline fields of ctx. A nil logger means the default logger;
+// see [SetDefaultLogger].
+func Debug(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	defaultStore.log(ctx, logger, zapcore.DebugLevel, msg, fields)
+}
+
+// Info logs msg at info level; see [Debug].
+func Info(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	defaultStore.log(ctx, logger, zapcore.InfoLevel, msg, fields)
+}
+
+// Warn logs msg at warn level; see [Debug].
+func Warn(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	defaultStore.log(ctx, logger, zapcore.WarnLevel, msg, fields)
+}
+
+// Error logs msg at error level; see [Debug].
+func Error(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	defaultStore.log(ctx, logger, zapcore.ErrorLevel, msg, fields)
+}
+
+// Debug logs msg at debug level with the fields of s in ctx; see [Debug].
+func (s *Store) Debug(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	s.log(ctx, logger, zapcore.DebugLevel, msg, fields)
+}
+
+// Info logs msg at info level with the fields of s in ctx; see [Info].
+func (s *Store) Info(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	s.log(ctx, logger, zapcore.InfoLevel, msg, fields)
+}
+
+// Warn logs msg at warn level with the fields of s in ctx; see [Warn].
+func (s *Store) Warn(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	s.log(ctx, logger, zapcore.WarnLevel, msg, fields)
+}
+
+// Error logs msg at error level with the fields of s in ctx; see [Error].
+func (s *Store) Error(ctx context.Context, logger *zap.Logger, msg string, fields ...zap.Field) {
+	s.log(ctx, logger, zapcore.ErrorLevel, msg, fields)
+}
+
+// Debugf formats a message according to template and args, like
+// [fmt.Sprintf], and logs it at debug level as [Debug] does. It eases
+// migrating from printf-style loggers; prefer fields for values worth
+// querying.
+func Debugf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	defaultStore.log(ctx, logger, zapcore.DebugLevel, formatf(ctx, logger, zapcore.DebugLevel, template, args), nil)
+}
+
+// Infof formats and logs a message at info level; see [Debugf].
+func Infof(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	defaultStore.log(ctx, logger, zapcore.InfoLevel, formatf(ctx, logger, zapcore.InfoLevel, template, args), nil)
+}
+
+// Warnf formats and logs a message at warn level; see [Debugf].
+func Warnf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	defaultStore.log(ctx, logger, zapcore.WarnLevel, formatf(ctx, logger, zapcore.WarnLevel, template, args), nil)
+}
+
+// Errorf formats and logs a message at error level; see [Debugf].
+func Errorf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	defaultStore.log(ctx, logger, zapcore.ErrorLevel, formatf(ctx, logger, zapcore.ErrorLevel, template, args), nil)
+}
+
+// Debugf formats and logs a message at debug level with the fields of s in
+// ctx; see [Debugf].
+func (s *Store) Debugf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	s.log(ctx, logger, zapcore.DebugLevel, formatf(ctx, logger, zapcore.DebugLevel, template, args), nil)
+}
+
+// Infof formats and logs a message at info level with the fields of s in
+// ctx; see [Infof].
+func (s *Store) Infof(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	s.log(ctx, logger, zapcore.InfoLevel, formatf(ctx, logger, zapcore.InfoLevel, template, args), nil)
+}
+
+// Warnf formats and logs a message at warn level with the fields of s in
+// ctx; see [Warnf].
+func (s *Store) Warnf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	s.log(ctx, logger, zapcore.WarnLevel, formatf(ctx, logger, zapcore.WarnLevel, template, args), nil)
+}
+
+// Errorf formats and logs a message at error level with the fields of s in
+// ctx; see [Errorf].
+func (s *Store) Errorf(ctx context.Context, logger *zap.Logger, template string, args ...any) {
+	s.log(ctx, logger, zapcore.ErrorLevel, formatf(ctx, logger, zapcore.ErrorLevel, template, args), nil)
+}
+
+// formatf formats template with args, unless entries at level are disabled
+// for logger and ctx, to save formatting entries that are dropped anyway.
+func formatf(ctx context.Context, logger *zap.Logger, level zapcore.Level, template string, args []any) string {
+	if !orDefault(logger).Core().Enabled(level) {
+		if override, ok := LevelFrom(ctx); !ok || level < override {
+			return ""
+		}
+	}
+	if len(args) == 0 {
+		return template
+	}
+	return fmt.Sprintf(template, args...)
+}
+
+// logCallerSkip skips log and its exported caller.
+const logCallerSkip = 2
+
+// log logs msg at level with the fields of s in ctx and fields. It must be
+// called directly by the exported logging functions, for callers to be
+// reported correctly.
+func (s *Store) log(ctx context.Context, logger *zap.Logger, level zapcore.Level, msg string, fields []zap.Field) {
+	logger = orDefault(logger)
+	cfg := s.loadConfig()
+	if cfg.placement == ContextFirst && !cfg.callSiteOverride {
+		// Fields attached by Logger come first, and are cached.
+		if ce := s.Logger(ctx, logger).WithOptions(zap.AddCallerSkip(logCallerSkip)).Check(level, msg); ce != nil {
+			ce.Write(fields...)
+		}
+		return
+	}
+	if cfg.sampling != nil && !cfg.sampling.keep(cfg, s.fromContext(ctx)) {
+		return
+	}
+	ce := s.decorate(ctx, cfg, logger).WithOptions(zap.AddCallerSkip(logCallerSkip)).Check(level, msg)
+	if ce == nil {
+		return
+	}
+	contextFields := cfg.attached(cfg.withDefaults(s.rawFields(ctx)))
+	if cfg.callSiteOverride && cfg.contextObject == "" {
+		contextFields = overridden(contextFields, fields)
+	}
+	combined := make([]zap.Field, 0, len(contextFields)+len(fields))
+	if cfg.placement == ContextLast {
+		combined = append(append(combined, fields...), contextFields...)
+	} else {
+		combined = append(append(combined, contextFields...), fields...)
+	}
+	ce.Write(combined...)
+}
+
+// overridden returns the fields of contextFields whose key isn't the key of
+// one of fields.
+func overridden(contextFields, fields []zap.Field) []zap.Field {
+	if len(fields) == 0 {
+		return contextFields
+	}
+	seen := make(map[string]struct{}, len(fields))
+	for _, field := range fields {
+		seen[field.Key] = struct{}{}
+	}
+	return pruneFields(contextFields, seen, nil)
+}
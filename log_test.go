@@ -0,0 +1,107 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"bytes"
+	"context"
+	"runtime"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func newJSONLogger(buf *bytes.Buffer) *zap.Logger {
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	return zap.New(zapcore.NewCore(enc, zapcore.AddSync(buf), zapcore.DebugLevel))
+}
+
+func TestLogPlacement(t *testing.T) {
+	tests := map[string]struct {
+		options  []Option
+		expected string
+	}{
+		"context first": {
+			expected: `{"msg":"placed","trace_id":"test-trace-id-3333","user_id":"u-1","user_id":"u-2"}`,
+		},
+		"context last": {
+			options:  []Option{WithPlacement(ContextLast)},
+			expected: `{"msg":"placed","user_id":"u-2","trace_id":"test-trace-id-3333","user_id":"u-1"}`,
+		},
+		"call site override": {
+			options:  []Option{WithCallSiteOverride(true)},
+			expected: `{"msg":"placed","trace_id":"test-trace-id-3333","user_id":"u-2"}`,
+		},
+		"context last with override": {
+			options:  []Option{WithPlacement(ContextLast), WithCallSiteOverride(true)},
+			expected: `{"msg":"placed","user_id":"u-2","trace_id":"test-trace-id-3333"}`,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			store := NewStore(tc.options...)
+			ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("user_id", "u-1")})
+
+			store.Info(ctx, newJSONLogger(&buf), "placed", zap.String("user_id", "u-2"))
+			assert.Equal(t, tc.expected+"\n", buf.String())
+		})
+	}
+}
+
+func TestLogLevels(t *testing.T) {
+	core, logs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core, zap.AddCaller())
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	_, _, line, _ := runtime.Caller(0)
+	Debug(ctx, logger, "debug")
+	Info(ctx, logger, "info")
+	Warn(ctx, logger, "warn")
+	Error(ctx, logger, "error", zap.Int("attempt", 2))
+	NewStore(WithPlacement(ContextLast)).Info(ctx, logger, "store")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 5)
+	for i, level := range []zapcore.Level{zapcore.DebugLevel, zapcore.InfoLevel, zapcore.WarnLevel, zapcore.ErrorLevel, zapcore.InfoLevel} {
+		assert.Equal(t, level, entries[i].Level)
+		assert.Equal(t, line+1+i, entries[i].Caller.Line, "the caller is the call site")
+		assert.Contains(t, entries[i].Caller.File, "log_test.go")
+	}
+	assert.Equal(t, map[string]any{traceIDKey: testTraceID, "attempt": int64(2)}, entries[3].ContextMap())
+	assert.Empty(t, entries[4].ContextMap(), "fields of another store")
+}
+
+func TestLogf(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core, zap.AddCaller())
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	_, _, line, _ := runtime.Caller(0)
+	Infof(ctx, logger, "user %s failed after %d tries", "u-1", 3)
+	Warnf(ctx, logger, "100%% literal")
+	Errorf(ctx, logger, "failed: %v", assert.AnError)
+	Debugf(ctx, logger, "disabled %s", "dropped")
+	Debugf(WithLevel(ctx, zapcore.DebugLevel), logger, "enabled %s", "by override")
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 4)
+	assert.Equal(t, "user u-1 failed after 3 tries", entries[0].Message)
+	assert.Equal(t, "100%% literal", entries[1].Message, "templates without args are logged as is")
+	assert.Equal(t, "failed: "+assert.AnError.Error(), entries[2].Message)
+	assert.Equal(t, "enabled by override", entries[3].Message)
+	assert.Equal(t, zapcore.DebugLevel, entries[3].Level)
+	for i, entry := range entries[:3] {
+		assert.Equal(t, line+1+i, entry.Caller.Line)
+		assert.Equal(t, map[string]any{traceIDKey: testTraceID}, entry.ContextMap())
+	}
+}
+
+func TestFormatfSkipsDisabled(t *testing.T) {
+	logger := zap.New(zapcore.NewNopCore())
+	assert.Empty(t, formatf(context.Background(), logger, zapcore.ErrorLevel, "%s", []any{"unused"}))
+}
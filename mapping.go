@@ -0,0 +1,78 @@
+package zax
+
+import (
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// KeyMapper maps the key of a stored field to the key it is exported with, so
+// that internal key names can differ from the log or wire schema.
+type KeyMapper func(string) string
+
+// WithKeyMapper makes the fields retrieved from contexts and attached to
+// loggers or exported carry keys mapped by mapper. Fields are stored, looked
+// up, serialized and injected into carriers with their unmapped keys, so
+// that they round-trip through other processes. A nil mapper disables
+// mapping.
+func WithKeyMapper(mapper KeyMapper) Option {
+	return func(c *config) {
+		c.keyMapper = mapper
+	}
+}
+
+// ChainMappers returns a mapper applying mappers in order.
+func ChainMappers(mappers ...KeyMapper) KeyMapper {
+	return func(key string) string {
+		for _, mapper := range mappers {
+			key = mapper(key)
+		}
+		return key
+	}
+}
+
+// RenameKeys returns a mapper renaming the keys in renames to their values,
+// leaving other keys unchanged.
+func RenameKeys(renames map[string]string) KeyMapper {
+	return func(key string) string {
+		if renamed, ok := renames[key]; ok {
+			return renamed
+		}
+		return key
+	}
+}
+
+// PrefixKeys returns a mapper prefixing keys with prefix.
+func PrefixKeys(prefix string) KeyMapper {
+	return func(key string) string {
+		return prefix + key
+	}
+}
+
+// StripPrefix returns a mapper removing prefix from the keys starting with
+// it.
+func StripPrefix(prefix string) KeyMapper {
+	return func(key string) string {
+		return strings.TrimPrefix(key, prefix)
+	}
+}
+
+// mapFields returns fields with mapped keys, copying them only if needed.
+func (c *config) mapFields(fields []zap.Field) []zap.Field {
+	if c.keyMapper == nil || len(fields) == 0 {
+		return fields
+	}
+	mapped := make([]zap.Field, len(fields))
+	for i, field := range fields {
+		mapped[i] = c.mapField(field)
+	}
+	return mapped
+}
+
+// mapField returns field with a mapped key.
+func (c *config) mapField(field zap.Field) zap.Field {
+	if c.keyMapper != nil {
+		field.Key = c.keyMapper(field.Key)
+	}
+	return field
+}
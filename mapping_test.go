@@ -0,0 +1,103 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestWithKeyMapper(t *testing.T) {
+	store := NewStore(WithKeyMapper(ChainMappers(
+		StripPrefix("internal."),
+		RenameKeys(map[string]string{traceIDKey: "trace.id"}),
+		PrefixKeys("app."),
+	)))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("internal.user", "alice")})
+
+	expected := []zap.Field{zap.String("app.trace.id", testTraceID), zap.String("app.user", "alice")}
+	assert.Equal(t, expected, store.GetAll(ctx))
+
+	field, ok := store.GetField(ctx, traceIDKey)
+	assert.True(t, ok, "fields are looked up by their stored key")
+	assert.Equal(t, zap.String("app.trace.id", testTraceID), field)
+
+	var ranged []zap.Field
+	store.Range(ctx, func(field zap.Field) bool {
+		ranged = append(ranged, field)
+		return true
+	})
+	assert.Equal(t, expected, ranged)
+
+	carrier := MapCarrier{}
+	store.Inject(ctx, carrier)
+	assert.Equal(t, MapCarrier{CarrierPrefix + traceIDKey: testTraceID, CarrierPrefix + "internal.user": "alice"}, carrier,
+		"carriers get the stored keys")
+
+	logs := newTestLogger(t)
+	store.Logger(ctx, logs.GetZapLogger()).Info("mapped")
+	logs.AssertLogEntryKeyExist(t, "app.trace.id")
+
+	forked := store.Fork(ctx, func(field zap.Field) (zap.Field, bool) { return field, true })
+	assert.Equal(t, expected, store.GetAll(forked), "forks don't map keys twice")
+}
+
+func TestKeyMapperRoundTrip(t *testing.T) {
+	store := NewStore(WithKeyMapper(PrefixKeys("app.")))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	roundTrips := map[string]func(context.Context) (context.Context, error){
+		"json": func(ctx context.Context) (context.Context, error) {
+			data, err := store.EncodeJSON(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return store.DecodeJSON(context.Background(), data)
+		},
+		"binary": func(ctx context.Context) (context.Context, error) {
+			data, err := store.EncodeBinary(ctx)
+			if err != nil {
+				return nil, err
+			}
+			return store.DecodeBinary(context.Background(), data)
+		},
+		"carrier": func(ctx context.Context) (context.Context, error) {
+			carrier := MapCarrier{}
+			store.Inject(ctx, carrier)
+			return store.Extract(context.Background(), carrier), nil
+		},
+	}
+	for name, roundTrip := range roundTrips {
+		t.Run(name, func(t *testing.T) {
+			restored, err := roundTrip(ctx)
+			require.NoError(t, err)
+			restored, err = roundTrip(restored)
+			require.NoError(t, err)
+
+			assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.StoredView(restored).Fields())
+			field, ok := store.GetField(restored, traceIDKey)
+			assert.True(t, ok)
+			assert.Equal(t, zap.String("app."+traceIDKey, testTraceID), field)
+		})
+	}
+}
+
+func TestKeyMapperEnvelope(t *testing.T) {
+	t.Cleanup(func() { Configure(WithKeyMapper(nil)) })
+	Configure(WithKeyMapper(PrefixKeys("app.")))
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	envelope, err := NewEnvelope(ctx, "job")
+	require.NoError(t, err)
+	restored, err := envelope.Context(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, StoredView(restored).Fields())
+
+	Bind(ctx, func(ctx context.Context) {
+		assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, StoredView(ctx).Fields())
+	})(context.Background())
+}
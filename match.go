@@ -0,0 +1,118 @@
+package zax
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"regexp"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// FieldEqual reports whether a and b have the same key, type and value.
+// Unlike [zap.Field.Equals], errors and fmt.Stringers are compared by their
+// string, and times by the instant they represent.
+func FieldEqual(a, b zap.Field) bool {
+	if a.Key != b.Key || a.Type != b.Type {
+		return false
+	}
+	switch a.Type {
+	case zapcore.ErrorType, zapcore.StringerType:
+		return fmt.Sprint(a.Interface) == fmt.Sprint(b.Interface)
+	case zapcore.TimeType, zapcore.TimeFullType:
+		return Value(a).(time.Time).Equal(Value(b).(time.Time))
+	}
+	return reflect.DeepEqual(Value(a), Value(b))
+}
+
+// FieldMatcher matches single fields; see [Match].
+type FieldMatcher func(zap.Field) bool
+
+// KeyIs matches the fields with key.
+func KeyIs(key string) FieldMatcher {
+	return func(field zap.Field) bool { return field.Key == key }
+}
+
+// TypeIs matches the fields of type typ.
+func TypeIs(typ zapcore.FieldType) FieldMatcher {
+	return func(field zap.Field) bool { return field.Type == typ }
+}
+
+// ValueMatches matches the fields whose value, in its string representation,
+// matches re.
+func ValueMatches(re *regexp.Regexp) FieldMatcher {
+	return func(field zap.Field) bool { return re.MatchString(textValue(field)) }
+}
+
+// Equals matches the fields equal to field; see [FieldEqual].
+func Equals(field zap.Field) FieldMatcher {
+	return func(f zap.Field) bool { return FieldEqual(f, field) }
+}
+
+// AllOf matches the fields matched by all of matchers.
+func AllOf(matchers ...FieldMatcher) FieldMatcher {
+	return func(field zap.Field) bool {
+		for _, matcher := range matchers {
+			if !matcher(field) {
+				return false
+			}
+		}
+		return true
+	}
+}
+
+// AnyOf matches the fields matched by any of matchers.
+func AnyOf(matchers ...FieldMatcher) FieldMatcher {
+	return func(field zap.Field) bool {
+		for _, matcher := range matchers {
+			if matcher(field) {
+				return true
+			}
+		}
+		return false
+	}
+}
+
+// Not matches the fields not matched by matcher.
+func Not(matcher FieldMatcher) FieldMatcher {
+	return func(field zap.Field) bool { return !matcher(field) }
+}
+
+// Match reports whether any field stored in ctx matches matcher, for example
+// to guard against requests lacking a well-formed tenant:
+//
+//	if !zax.Match(ctx, zax.AllOf(zax.KeyIs("tenant_id"), zax.ValueMatches(tenantPattern))) {
+//		return errNoTenant
+//	}
+func Match(ctx context.Context, matcher FieldMatcher) bool {
+	return defaultStore.Match(ctx, matcher)
+}
+
+// Match reports whether any field of s in ctx matches matcher; see [Match].
+func (s *Store) Match(ctx context.Context, matcher FieldMatcher) bool {
+	matched := false
+	s.Range(ctx, func(field zap.Field) bool {
+		matched = matcher(field)
+		return !matched
+	})
+	return matched
+}
+
+// Filter returns the fields stored in ctx matching matcher.
+func Filter(ctx context.Context, matcher FieldMatcher) []zap.Field {
+	return defaultStore.Filter(ctx, matcher)
+}
+
+// Filter returns the fields of s in ctx matching matcher; see [Filter].
+func (s *Store) Filter(ctx context.Context, matcher FieldMatcher) []zap.Field {
+	var fields []zap.Field
+	s.Range(ctx, func(field zap.Field) bool {
+		if matcher(field) {
+			fields = append(fields, field)
+		}
+		return true
+	})
+	return fields
+}
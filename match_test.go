@@ -0,0 +1,57 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestFieldEqual(t *testing.T) {
+	now := time.Now()
+	tests := map[string]struct {
+		a, b  zap.Field
+		equal bool
+	}{
+		"same":            {zap.String("k", "v"), zap.String("k", "v"), true},
+		"other value":     {zap.String("k", "v"), zap.String("k", "w"), false},
+		"other key":       {zap.String("k", "v"), zap.String("j", "v"), false},
+		"other type":      {zap.Int32("k", 1), zap.Int64("k", 1), false},
+		"errors":          {zap.Error(errors.New("failed")), zap.Error(errors.New("failed")), true},
+		"stringers":       {zap.Stringer("k", stringer{}), zap.Stringer("k", stringer{}), true},
+		"times":           {zap.Time("k", now), zap.Time("k", now.UTC()), true},
+		"arrays":          {zap.Strings("k", []string{"a"}), zap.Strings("k", []string{"a"}), true},
+		"other arrays":    {zap.Strings("k", []string{"a"}), zap.Strings("k", []string{"b"}), false},
+		"floats":          {zap.Float64("k", 1.5), zap.Float64("k", 1.5), true},
+		"other durations": {zap.Duration("k", time.Second), zap.Duration("k", time.Minute), false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, tt.equal, FieldEqual(tt.a, tt.b))
+		})
+	}
+}
+
+func TestMatch(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{
+		zap.String("tenant_id", "acme-42"),
+		zap.Int("attempt", 2),
+		zap.String(traceIDKey, testTraceID),
+	})
+	tenant := AllOf(KeyIs("tenant_id"), ValueMatches(regexp.MustCompile(`^[a-z]+-\d+$`)))
+
+	assert.True(t, Match(ctx, tenant))
+	assert.False(t, Match(ctx, AllOf(KeyIs("tenant_id"), TypeIs(zapcore.Int64Type))))
+	assert.True(t, Match(ctx, Equals(zap.Int("attempt", 2))))
+	assert.False(t, Match(context.Background(), tenant))
+
+	assert.Equal(t, []zap.Field{zap.String("tenant_id", "acme-42"), zap.String(traceIDKey, testTraceID)}, Filter(ctx, TypeIs(zapcore.StringType)))
+	assert.Equal(t, []zap.Field{zap.Int("attempt", 2)}, Filter(ctx, Not(AnyOf(KeyIs("tenant_id"), KeyIs(traceIDKey)))))
+}
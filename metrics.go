@@ -0,0 +1,19 @@
+package zax
+
+// AbsentCounter counts lookups of fields that are not in the context, so that
+// services can monitor where expected correlation fields are not being set.
+// Implementations must be safe for concurrent use; see the zaxprom package
+// for a Prometheus adapter.
+type AbsentCounter interface {
+	// Inc records a lookup of key that found no field.
+	Inc(key string)
+}
+
+// WithAbsentCounter makes [GetField] misses, and so absent keys recorded by
+// [GetFields] and [AppendFields], increment counter. A nil counter disables
+// counting.
+func WithAbsentCounter(counter AbsentCounter) Option {
+	return func(c *config) {
+		c.absentCounter = counter
+	}
+}
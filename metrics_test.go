@@ -0,0 +1,35 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+type mapCounter struct {
+	mu     sync.Mutex
+	counts map[string]int
+}
+
+func (m *mapCounter) Inc(key string) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.counts[key]++
+}
+
+func TestWithAbsentCounter(t *testing.T) {
+	counter := &mapCounter{counts: map[string]int{}}
+	store := NewStore(WithAbsentCounter(counter))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	store.GetFields(ctx, traceIDKey, spanIDKey)
+	store.GetField(ctx, spanIDKey)
+	store.GetField(context.Background(), traceIDKey)
+
+	assert.Equal(t, map[string]int{spanIDKey: 2, traceIDKey: 1}, counter.counts)
+}
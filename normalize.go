@@ -0,0 +1,113 @@
+package zax
+
+import (
+	"strings"
+	"unicode"
+
+	"go.uber.org/zap"
+)
+
+// WithKeyNormalizer makes Set and Append store fields with keys normalized by
+// normalizer, before they are validated, so that fields from headers or user
+// input produce consistent keys. Unlike [WithKeyMapper], it changes the keys
+// fields are stored, looked up and extracted with. Normalizers can be
+// combined with [ChainMappers]. A nil normalizer disables normalization.
+func WithKeyNormalizer(normalizer KeyMapper) Option {
+	return func(c *config) {
+		c.keyNormalizer = normalizer
+	}
+}
+
+// LowerCaseKeys is a normalizer converting keys to lower case.
+func LowerCaseKeys(key string) string {
+	return strings.ToLower(key)
+}
+
+// SnakeCaseKeys is a normalizer converting keys to snake_case: words,
+// delimited by case changes or by any character other than an ASCII letter
+// or digit, are lower-cased and joined by single underscores. For instance,
+// "X-Request-ID" and "requestID" become "x_request_id" and "request_id".
+func SnakeCaseKeys(key string) string {
+	if _, err := SnakeCase(key); err == nil {
+		return key
+	}
+	var b strings.Builder
+	b.Grow(len(key) + 4)
+	pending := false
+	for i := 0; i < len(key); i++ {
+		if !isASCIIAlnum(key[i]) {
+			pending = b.Len() > 0
+			continue
+		}
+		if pending || (b.Len() > 0 && startsWord(key, i)) {
+			b.WriteByte('_')
+			pending = false
+		}
+		b.WriteByte(toASCIILower(key[i]))
+	}
+	return b.String()
+}
+
+// startsWord reports whether the letter or digit at i > 0 in key starts a
+// camelCase word, as "I" does in "requestID" and "R" in "HTTPRequest".
+func startsWord(key string, i int) bool {
+	if !isASCIIUpper(key[i]) {
+		return false
+	}
+	prev := key[i-1]
+	return isASCIILower(prev) || isASCIIDigit(prev) || (isASCIIUpper(prev) && i+1 < len(key) && isASCIILower(key[i+1]))
+}
+
+// StripIllegal returns a normalizer removing the characters of keys for
+// which legal returns false.
+func StripIllegal(legal func(r rune) bool) KeyMapper {
+	return func(key string) string {
+		return strings.Map(func(r rune) rune {
+			if legal(r) {
+				return r
+			}
+			return -1
+		}, key)
+	}
+}
+
+// Printable reports whether r is a printable character other than a space;
+// see [StripIllegal].
+func Printable(r rune) bool {
+	return unicode.IsPrint(r) && !unicode.IsSpace(r)
+}
+
+func isASCIILower(b byte) bool { return 'a' <= b && b <= 'z' }
+func isASCIIUpper(b byte) bool { return 'A' <= b && b <= 'Z' }
+func isASCIIDigit(b byte) bool { return '0' <= b && b <= '9' }
+func isASCIIAlnum(b byte) bool { return isASCIILower(b) || isASCIIUpper(b) || isASCIIDigit(b) }
+
+func toASCIILower(b byte) byte {
+	if isASCIIUpper(b) {
+		return b + 'a' - 'A'
+	}
+	return b
+}
+
+// normalizeKeys returns fields with normalized keys, copying them only if a
+// key changes.
+func (c *config) normalizeKeys(fields []zap.Field) []zap.Field {
+	if c.keyNormalizer == nil {
+		return fields
+	}
+	var normalized []zap.Field
+	for i, field := range fields {
+		key := c.keyNormalizer(field.Key)
+		if key == field.Key {
+			continue
+		}
+		if normalized == nil {
+			normalized = append([]zap.Field(nil), fields...)
+		}
+		normalized[i].Key = key
+	}
+	if normalized == nil {
+		return fields
+	}
+	return normalized
+}
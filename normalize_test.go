@@ -0,0 +1,67 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestSnakeCaseKeys(t *testing.T) {
+	for key, want := range map[string]string{
+		"X-Request-ID":  "x_request_id",
+		"requestID":     "request_id",
+		"HTTPStatus":    "http_status",
+		"user.name":     "user_name",
+		"  trace  id ":  "trace_id",
+		"already_snake": "already_snake",
+		"v2Api":         "v2_api",
+		"--":            "",
+	} {
+		got := SnakeCaseKeys(key)
+		assert.Equal(t, want, got, key)
+		if want != "" {
+			_, err := SnakeCase(got)
+			assert.NoError(t, err, key)
+		}
+	}
+}
+
+func TestStripIllegal(t *testing.T) {
+	assert.Equal(t, "user.id", StripIllegal(Printable)("user .id\n\x00"))
+	assert.Equal(t, "key", LowerCaseKeys("KeY"))
+}
+
+func TestWithKeyNormalizer(t *testing.T) {
+	store := NewStore(WithKeyNormalizer(ChainMappers(StripIllegal(Printable), SnakeCaseKeys)))
+	fields := []zap.Field{zap.String("X-Request-ID", "abc"), zap.String("user_id", "42")}
+
+	ctx := store.Set(context.Background(), fields)
+	ctx = store.Append(ctx, []zap.Field{zap.String("Retry Attempt", "1")})
+
+	assert.Equal(t, "X-Request-ID", fields[0].Key, "the fields of the caller are left unchanged")
+	assert.Equal(t, "abc", getString(t, store, ctx, "x_request_id"))
+	assert.Equal(t, "42", getString(t, store, ctx, "user_id"))
+	assert.Equal(t, "1", getString(t, store, ctx, "retry_attempt"))
+	_, ok := store.GetField(ctx, "X-Request-ID")
+	assert.False(t, ok)
+}
+
+func TestWithKeyNormalizerBeforeValidation(t *testing.T) {
+	store := NewStore(WithKeyNormalizer(SnakeCaseKeys), WithKeyValidator(SnakeCase, ValidationPanic))
+
+	ctx, err := store.TrySet(context.Background(), []zap.Field{zap.String("traceID", testTraceID)})
+
+	assert.NoError(t, err)
+	assert.Equal(t, testTraceID, getString(t, store, ctx, "trace_id"))
+}
+
+func getString(t *testing.T, store *Store, ctx context.Context, key string) string {
+	t.Helper()
+	field, ok := store.GetField(ctx, key)
+	assert.True(t, ok, key)
+	return field.String
+}
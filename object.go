@@ -0,0 +1,59 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Object returns a marshaler rendering the fields stored in ctx as a single
+// object, to nest them under one key instead of attaching them at the top
+// level:
+//
+//	logger.Info("request served", zap.Object("ctx", zax.Object(ctx)))
+func Object(ctx context.Context) zapcore.ObjectMarshaler {
+	return defaultStore.Object(ctx)
+}
+
+// Object returns a marshaler rendering the fields of s in ctx; see [Object].
+func (s *Store) Object(ctx context.Context) zapcore.ObjectMarshaler {
+	return fieldsObject(s.View(ctx).Unsafe())
+}
+
+// ContextObjectKey is the conventional key of the object holding the fields
+// attached by [Logger]; see [WithContextObject].
+const ContextObjectKey = "ctx"
+
+// WithContextObject makes [Logger] attach the fields stored in contexts as a
+// single object under key, such as [ContextObjectKey], instead of flattening
+// them at the top level of entries, so that log schemas tell ambient context
+// apart from the fields of each event:
+//
+//	{"level":"info","msg":"order placed","order_id":7,"ctx":{"trace_id":"4bf92f35"}}
+//
+// An empty key restores flattening.
+func WithContextObject(key string) Option {
+	return func(c *config) {
+		c.contextObject = key
+	}
+}
+
+// attached returns the fields attached by Logger for fields.
+func (c *config) attached(fields []zap.Field) []zap.Field {
+	fields = c.mapFields(fields)
+	if c.contextObject == "" {
+		return fields
+	}
+	return []zap.Field{zap.Object(c.contextObject, fieldsObject(fields))}
+}
+
+// fieldsObject marshals fields as an object.
+type fieldsObject []zap.Field
+
+func (f fieldsObject) MarshalLogObject(enc zapcore.ObjectEncoder) error {
+	for _, field := range f {
+		field.AddTo(enc)
+	}
+	return nil
+}
@@ -0,0 +1,43 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestObject(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 2)})
+
+	enc := zapcore.NewMapObjectEncoder()
+	require.NoError(t, enc.AddObject("ctx", Object(ctx)))
+	assert.Equal(t, map[string]interface{}{
+		"ctx": map[string]interface{}{traceIDKey: testTraceID, "attempt": int64(2)},
+	}, enc.Fields)
+
+	enc = zapcore.NewMapObjectEncoder()
+	require.NoError(t, enc.AddObject("ctx", Object(context.Background())))
+	assert.Equal(t, map[string]interface{}{"ctx": map[string]interface{}{}}, enc.Fields)
+}
+
+func TestWithContextObject(t *testing.T) {
+	var buf bytes.Buffer
+	enc := zapcore.NewJSONEncoder(zapcore.EncoderConfig{MessageKey: "msg"})
+	logger := zap.New(zapcore.NewCore(enc, zapcore.AddSync(&buf), zapcore.DebugLevel))
+	store := NewStore(WithContextObject(ContextObjectKey), WithDefaults(zap.String("service", "billing")))
+
+	store.Logger(store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)}), logger).Info("order placed", zap.Int("order_id", 7))
+	store.Logger(context.Background(), logger).Info("defaults only")
+
+	assert.Equal(t, ""+
+		`{"msg":"order placed","ctx":{"trace_id":"test-trace-id-3333","service":"billing"},"order_id":7}`+"\n"+
+		`{"msg":"defaults only","ctx":{"service":"billing"}}`+"\n",
+		buf.String())
+}
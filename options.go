@@ -0,0 +1,167 @@
+package zax
+
+import "go.uber.org/zap"
+
+// Redacted replaces the values of the fields redacted by [WithRedactedKeys].
+const Redacted = "[REDACTED]"
+
+// Option configures the behavior of a [Store].
+type Option func(*config)
+
+// NewStore returns a new, empty store configured by opts.
+func NewStore(opts ...Option) *Store {
+	s := &Store{key: &storeKey{}}
+	s.Configure(opts...)
+	return s
+}
+
+// Configure applies opts to the default store.
+func Configure(opts ...Option) {
+	defaultStore.Configure(opts...)
+}
+
+// Configure applies opts to s. Options only affect subsequent operations.
+func (s *Store) Configure(opts ...Option) {
+	if len(opts) == 0 {
+		return
+	}
+	s.updateConfig(Options(opts...))
+}
+
+// Options combines opts into a single option, applied in order. It lets
+// packages extending zax provide a single option configuring several
+// features.
+func Options(opts ...Option) Option {
+	return func(c *config) {
+		for _, opt := range opts {
+			opt(c)
+		}
+	}
+}
+
+// WithLimits sets the limits enforced on writes; see [Limits].
+func WithLimits(limits Limits) Option {
+	return func(c *config) {
+		c.limits = limits
+	}
+}
+
+// WithInterning enables or disables interning of written keys and string
+// values; see [SetInterning].
+func WithInterning(enabled bool) Option {
+	return func(c *config) {
+		c.interning = enabled
+	}
+}
+
+// WithKeyValidator sets the validator applied to written keys, and what
+// happens to rejected fields; see [SetKeyValidator].
+func WithKeyValidator(validator KeyValidator, mode ValidationMode) Option {
+	return func(c *config) {
+		c.validator = validator
+		c.validation = mode
+	}
+}
+
+// WithSetHook adds a hook called by every Set; see [OnSet].
+func WithSetHook(hook Hook) Option {
+	return func(c *config) {
+		c.setHooks = append(c.setHooks[:len(c.setHooks):len(c.setHooks)], hook)
+	}
+}
+
+// WithAppendHook adds a hook called by every Append; see [OnAppend].
+func WithAppendHook(hook Hook) Option {
+	return func(c *config) {
+		c.appendHooks = append(c.appendHooks[:len(c.appendHooks):len(c.appendHooks)], hook)
+	}
+}
+
+// WithCollisionHandler sets the handler called when Append shadows a field;
+// see [OnCollision].
+func WithCollisionHandler(handler CollisionHandler) Option {
+	return func(c *config) {
+		c.onCollision = handler
+	}
+}
+
+// WithCollisionMarking enables or disables recording shadowed keys in the
+// stored fields; see [MarkCollisions].
+func WithCollisionMarking(enabled bool) Option {
+	return func(c *config) {
+		c.markCollisions = enabled
+	}
+}
+
+// WithErrorStacktrace sets the stack trace policy of [LogError].
+func WithErrorStacktrace(policy StacktracePolicy) Option {
+	return func(c *config) {
+		c.errorStacktrace = policy
+	}
+}
+
+// WithRedactedKeys makes writes replace the value of the fields with any of
+// keys by [Redacted], so that sensitive values never reach the logs. Calling
+// it again replaces the set of redacted keys.
+func WithRedactedKeys(keys ...string) Option {
+	return func(c *config) {
+		c.redacted = keySet(keys)
+	}
+}
+
+// WithPropagatedKeys restricts the fields written by Inject and read by
+// Extract to the ones with any of keys, so that internal fields don't leak to
+// other services and callers can't inject arbitrary fields. Without keys,
+// all fields are propagated.
+func WithPropagatedKeys(keys ...string) Option {
+	return func(c *config) {
+		c.propagated = keySet(keys)
+	}
+}
+
+// WithOmitAbsentFields makes [GetFields] and [AppendFields] return only the
+// found fields, leaving out the [AbsentFieldsKey] field listing missing keys.
+func WithOmitAbsentFields(enabled bool) Option {
+	return func(c *config) {
+		c.omitAbsent = enabled
+	}
+}
+
+// keySet returns the set of keys, or nil if there are none.
+func keySet(keys []string) map[string]bool {
+	if len(keys) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(keys))
+	for _, key := range keys {
+		set[key] = true
+	}
+	return set
+}
+
+// redact returns a copy of fields with redacted values, if any needs to be.
+func (c *config) redact(fields []zap.Field) []zap.Field {
+	if c.redacted == nil {
+		return fields
+	}
+	var redacted []zap.Field
+	for i, field := range fields {
+		if !c.redacted[field.Key] {
+			continue
+		}
+		if redacted == nil {
+			redacted = append([]zap.Field(nil), fields...)
+		}
+		redacted[i] = zap.String(field.Key, Redacted)
+	}
+	if redacted == nil {
+		return fields
+	}
+	return redacted
+}
+
+// propagates reports whether the field with key may cross process
+// boundaries.
+func (c *config) propagates(key string) bool {
+	return c.propagated == nil || c.propagated[key]
+}
@@ -0,0 +1,75 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStoreOptions(t *testing.T) {
+	var appended []string
+	store := NewStore(
+		WithLimits(Limits{MaxFields: 3}),
+		WithKeyValidator(SnakeCase, ValidationDrop),
+		WithAppendHook(func(_ context.Context, event WriteEvent) {
+			appended = append(appended, event.Keys()...)
+		}),
+		WithRedactedKeys("password"),
+	)
+
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("password", "hunter2")})
+	ctx = store.Append(ctx, []zap.Field{zap.String("spanID", "dropped"), zap.Int("attempt", 1), zap.Int("extra", 2)})
+
+	assert.Equal(t, []zap.Field{
+		zap.Int("attempt", 1),
+		zap.Int("extra", 2),
+		zap.String(traceIDKey, testTraceID),
+	}, store.GetAll(ctx))
+	assert.Equal(t, []string{"attempt", "extra"}, appended)
+
+	redacted := store.Set(context.Background(), []zap.Field{zap.String("password", "hunter2")})
+	assert.Equal(t, []zap.Field{zap.String("password", Redacted)}, store.GetAll(redacted))
+
+	// The default store is not affected.
+	assert.Len(t, GetAll(Set(context.Background(), []zap.Field{zap.String("password", "p"), zap.String("a", "a"), zap.String("b", "b"), zap.String("c", "c")})), 4)
+}
+
+func TestConfigure(t *testing.T) {
+	t.Cleanup(func() { Configure(WithRedactedKeys()) })
+	Configure(WithRedactedKeys("token"))
+
+	fields := []zap.Field{zap.String("token", "secret"), zap.String(traceIDKey, testTraceID)}
+	ctx := Set(context.Background(), fields)
+	assert.Equal(t, []zap.Field{zap.String("token", Redacted), zap.String(traceIDKey, testTraceID)}, GetAll(ctx))
+	assert.Equal(t, "secret", fields[0].String, "the caller's fields are left untouched")
+}
+
+func TestWithPropagatedKeys(t *testing.T) {
+	store := NewStore(WithPropagatedKeys(traceIDKey))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("internal", "value")})
+
+	carrier := MapCarrier{}
+	store.Inject(ctx, carrier)
+	assert.Equal(t, MapCarrier{CarrierPrefix + traceIDKey: testTraceID}, carrier)
+
+	carrier[CarrierPrefix+"injected"] = "value"
+	extracted := store.Extract(context.Background(), carrier)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.GetAll(extracted))
+}
+
+func TestWithOmitAbsentFields(t *testing.T) {
+	store := NewStore(WithOmitAbsentFields(true))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.GetFields(ctx, traceIDKey, spanIDKey))
+
+	store.Configure(WithOmitAbsentFields(false))
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.Strings(AbsentFieldsKey, []string{spanIDKey}),
+	}, store.GetFields(ctx, traceIDKey, spanIDKey))
+}
@@ -0,0 +1,62 @@
+package zax
+
+import (
+	"context"
+	"runtime"
+)
+
+// WithProvenance enables or disables recording the caller of every write, to
+// diagnose which code set a field; see [Provenance]. Recording walks the
+// stack on every write, so it is meant for debugging.
+func WithProvenance(enabled bool) Option {
+	return func(c *config) {
+		c.provenance = enabled
+	}
+}
+
+// provenanceKey keys the provenance of the writes to the store with key.
+type provenanceKey struct {
+	key any
+}
+
+// provenance records the caller of a write of keys, newest first.
+type provenance struct {
+	keys   []string
+	caller runtime.Frame
+	parent *provenance
+}
+
+// Provenance returns, for each field of the default store in ctx, the caller
+// that set its current value. Only writes made while provenance was enabled
+// with [WithProvenance] are recorded.
+func Provenance(ctx context.Context) map[string]runtime.Frame {
+	return defaultStore.Provenance(ctx)
+}
+
+// Provenance returns the callers that set the fields of s in ctx; see
+// [Provenance].
+func (s *Store) Provenance(ctx context.Context) map[string]runtime.Frame {
+	p, _ := ctx.Value(provenanceKey{s.key}).(*provenance)
+	if p == nil {
+		return nil
+	}
+	present := make(map[string]bool)
+	for _, field := range s.rawFields(ctx) {
+		present[field.Key] = true
+	}
+	callers := make(map[string]runtime.Frame)
+	for ; p != nil; p = p.parent {
+		for _, key := range p.keys {
+			if _, ok := callers[key]; !ok && present[key] {
+				callers[key] = p.caller
+			}
+		}
+	}
+	return callers
+}
+
+// recordProvenance returns ctx recording the caller of a write of keys.
+func (s *Store) recordProvenance(ctx context.Context, keys []string) context.Context {
+	parent, _ := ctx.Value(provenanceKey{s.key}).(*provenance)
+	return context.WithValue(ctx, provenanceKey{s.key}, &provenance{keys: keys, caller: callerFrame(), parent: parent})
+}
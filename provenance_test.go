@@ -0,0 +1,34 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestProvenance(t *testing.T) {
+	store := NewStore()
+	ctx := store.Set(context.Background(), []zap.Field{zap.String("untracked", "value")})
+	assert.Nil(t, store.Provenance(ctx), "provenance is disabled by default")
+
+	store.Configure(WithProvenance(true), WithLimits(Limits{MaxFields: 3}))
+	ctx = store.Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("user_id", "alice")})
+	ctx = overwriteUser(store, ctx)
+	ctx = store.Append(ctx, []zap.Field{zap.String(spanIDKey, "span")})
+
+	callers := store.Provenance(ctx)
+	require.Len(t, callers, 3)
+	assert.Equal(t, "github.com/yuseferi/zax/v2.TestProvenance", callers[traceIDKey].Function)
+	assert.Equal(t, "github.com/yuseferi/zax/v2.overwriteUser", callers["user_id"].Function)
+	assert.Contains(t, callers[spanIDKey].File, "provenance_test.go")
+	assert.NotContains(t, callers, "untracked")
+}
+
+func overwriteUser(store *Store, ctx context.Context) context.Context {
+	return store.Append(ctx, []zap.Field{zap.String("user_id", "mallory")})
+}
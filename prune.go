@@ -0,0 +1,65 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Prune returns a copy of ctx keeping only the newest stored field of each
+// key. Append keeps shadowed fields, so contexts appended to in loops, such as
+// retries, otherwise grow without bound and log duplicate keys; see also
+// [WithAutoPrune].
+//
+// If keys are given, only the fields with those keys are deduplicated, leaving
+// intentionally repeated fields, such as multiple tags, untouched.
+//
+// Prune is stable: the surviving fields keep their original order, so that
+// console output reads the same before and after pruning.
+func Prune(ctx context.Context, keys ...string) context.Context {
+	return defaultStore.Prune(ctx, keys...)
+}
+
+// Prune returns a copy of ctx keeping only the newest field of s of each key;
+// see [Prune].
+func (s *Store) Prune(ctx context.Context, keys ...string) context.Context {
+	c := s.fromContext(ctx)
+	if c == nil {
+		return ctx
+	}
+	s.loadConfig().stats.recordPrune()
+	seen := make(map[string]struct{}, len(c.fields))
+	only := keySet(keys)
+	next := c.with(nil, pruneFields(c.unscoped, seen, only))
+	for _, part := range c.scopes {
+		next = next.with(part.scope, pruneFields(part.fields, seen, only))
+	}
+	return context.WithValue(ctx, s.key, next)
+}
+
+// WithAutoPrune makes every write keep only the newest field of each key,
+// as if followed by [Prune].
+func WithAutoPrune(enabled bool) Option {
+	return func(c *config) {
+		c.autoPrune = enabled
+	}
+}
+
+// pruneFields returns fields without the fields whose key is in seen or
+// appeared earlier, adding the kept keys to seen. Unless only is nil, fields
+// with keys not in only are all kept.
+func pruneFields(fields []zap.Field, seen map[string]struct{}, only map[string]bool) []zap.Field {
+	pruned := make([]zap.Field, 0, len(fields))
+	for _, field := range fields {
+		if only != nil && !only[field.Key] {
+			pruned = append(pruned, field)
+			continue
+		}
+		if _, ok := seen[field.Key]; ok {
+			continue
+		}
+		seen[field.Key] = struct{}{}
+		pruned = append(pruned, field)
+	}
+	return pruned
+}
@@ -0,0 +1,82 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestPrune(t *testing.T) {
+	scope := NewScope("request")
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 0)})
+	for attempt := 1; attempt <= 3; attempt++ {
+		ctx = Append(ctx, []zap.Field{zap.Int("attempt", attempt)})
+	}
+	ctx = scope.Set(ctx, []zap.Field{zap.String("user_id", "alice"), zap.Int("attempt", -1)})
+	assert.Len(t, GetAll(ctx), 7)
+
+	pruned := Prune(ctx)
+	assert.Equal(t, []zap.Field{
+		zap.Int("attempt", 3),
+		zap.String(traceIDKey, testTraceID),
+		zap.String("user_id", "alice"),
+	}, GetAll(pruned))
+	assert.Equal(t, []zap.Field{zap.String("user_id", "alice")}, scope.Get(pruned))
+	assert.Len(t, GetAll(ctx), 7, "the original context is untouched")
+
+	assert.Equal(t, context.Background(), Prune(context.Background()))
+}
+
+func TestWithAutoPrune(t *testing.T) {
+	store := NewStore(WithAutoPrune(true), WithStats(true))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	for attempt := 1; attempt <= 3; attempt++ {
+		ctx = store.Append(ctx, []zap.Field{zap.Int("attempt", attempt)})
+	}
+
+	assert.Equal(t, []zap.Field{zap.Int("attempt", 3), zap.String(traceIDKey, testTraceID)}, store.GetAll(ctx))
+
+	store.Prune(ctx)
+	assert.Equal(t, uint64(1), store.Stats().Prunes)
+}
+
+func TestPruneKeys(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String("tag", "a"), zap.Int("attempt", 0)})
+	ctx = Append(ctx, []zap.Field{zap.String("tag", "b"), zap.Int("attempt", 1)})
+
+	assert.Equal(t, []zap.Field{
+		zap.String("tag", "b"),
+		zap.Int("attempt", 1),
+		zap.String("tag", "a"),
+	}, GetAll(Prune(ctx, "attempt")))
+}
+
+func TestPruneKeepsOrder(t *testing.T) {
+	fields := []zap.Field{
+		zap.String("a", "1"),
+		zap.String("b", "1"),
+		zap.String("a", "2"),
+		zap.String("c", "1"),
+		zap.String("b", "2"),
+		zap.String("d", "1"),
+	}
+	ctx := Set(context.Background(), fields)
+
+	assert.Equal(t, []zap.Field{
+		zap.String("a", "1"),
+		zap.String("b", "1"),
+		zap.String("c", "1"),
+		zap.String("d", "1"),
+	}, GetAll(Prune(ctx)))
+	assert.Equal(t, []zap.Field{
+		zap.String("a", "1"),
+		zap.String("b", "1"),
+		zap.String("c", "1"),
+		zap.String("b", "2"),
+		zap.String("d", "1"),
+	}, GetAll(Prune(ctx, "a")))
+}
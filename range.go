@@ -0,0 +1,30 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Range calls fn for each field stored in ctx, newest first, then for each
+// default not overridden by a stored field, until fn returns false. Unlike
+// [GetAll], it doesn't allocate; with Go 1.23 or later, see also [All].
+func Range(ctx context.Context, fn func(zap.Field) bool) {
+	defaultStore.Range(ctx, fn)
+}
+
+// Range calls fn for each field of s in ctx; see [Range].
+func (s *Store) Range(ctx context.Context, fn func(zap.Field) bool) {
+	cfg := s.loadConfig()
+	fields := s.rawFields(ctx)
+	for _, field := range fields {
+		if !fn(cfg.mapField(field)) {
+			return
+		}
+	}
+	for _, def := range cfg.defaults {
+		if !hasKey(fields, def.Key) && !fn(cfg.mapField(def)) {
+			return
+		}
+	}
+}
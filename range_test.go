@@ -0,0 +1,55 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestRange(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String(spanIDKey, "span")})
+
+	var keys []string
+	Range(ctx, func(field zap.Field) bool {
+		keys = append(keys, field.Key)
+		return true
+	})
+	assert.Equal(t, []string{traceIDKey, spanIDKey}, keys)
+
+	keys = nil
+	Range(ctx, func(field zap.Field) bool {
+		keys = append(keys, field.Key)
+		return false
+	})
+	assert.Equal(t, []string{traceIDKey}, keys)
+
+	Range(context.Background(), func(zap.Field) bool {
+		t.Fatal("unexpected field")
+		return true
+	})
+}
+
+func TestRangeDefaults(t *testing.T) {
+	store := NewStore(WithDefaults(zap.String("service", "api"), zap.String(spanIDKey, "default")))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.String(spanIDKey, "span")})
+
+	var fields []zap.Field
+	store.Range(ctx, func(field zap.Field) bool {
+		fields = append(fields, field)
+		return true
+	})
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.String(spanIDKey, "span"),
+		zap.String("service", "api"),
+	}, fields)
+
+	allocs := testing.AllocsPerRun(10, func() {
+		store.Range(ctx, func(zap.Field) bool { return true })
+	})
+	assert.Zero(t, allocs)
+}
@@ -0,0 +1,153 @@
+package zax
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// maxBuckets bounds the number of values tracked by a rate limiter; beyond
+// it, the buckets of idle values are dropped.
+const maxBuckets = 1 << 14
+
+// NewRateLimiter returns a core limiting the entries carrying each value of
+// key, typically a trace or tenant ID, to rate per second, with bursts of up
+// to burst entries, so that one noisy request cannot drown the log pipeline.
+// Entries beyond the limit are dropped; entries without key are always kept.
+//
+// Like [NewTraceSampler], the value is looked up in the fields attached with
+// [Logger] or [zap.Logger.With] as well as in the fields of each entry.
+func NewRateLimiter(core zapcore.Core, key string, rate float64, burst int) zapcore.Core {
+	return &keyedLimiter{
+		Core:   core,
+		key:    key,
+		policy: &buckets{rate: rate, burst: float64(burst), levels: make(map[string]*bucket)},
+	}
+}
+
+// limitPolicy decides which entries of each value of a key a [keyedLimiter]
+// lets through.
+type limitPolicy interface {
+	// allow reports whether an entry with value logged at now is let through.
+	allow(value string, now time.Time) bool
+}
+
+// keyedLimiter is a core letting through the entries carrying each value of
+// key according to its policy, and every entry without key.
+type keyedLimiter struct {
+	zapcore.Core
+	key    string
+	policy limitPolicy
+	// value is the value of key in the attached fields, if decided.
+	value   string
+	decided bool
+}
+
+func (l *keyedLimiter) With(fields []zapcore.Field) zapcore.Core {
+	clone := *l
+	clone.Core = l.Core.With(fields)
+	if value, ok := l.lookup(fields); ok {
+		clone.value, clone.decided = value, true
+	}
+	return &clone
+}
+
+func (l *keyedLimiter) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if !l.Enabled(ent.Level) {
+		return ce
+	}
+	if l.decided {
+		if !l.policy.allow(l.value, ent.Time) {
+			return ce
+		}
+		return l.Core.Check(ent, ce)
+	}
+	// The value depends on the fields of the entry, only known by Write.
+	return filterThrough(l.Core, ent, ce, l.allows)
+}
+
+func (l *keyedLimiter) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !l.decided && !l.allows(ent, fields) {
+		return nil
+	}
+	return l.Core.Write(ent, fields)
+}
+
+// allows reports whether the policy lets through the entry with fields.
+func (l *keyedLimiter) allows(ent zapcore.Entry, fields []zapcore.Field) bool {
+	value, ok := l.lookup(fields)
+	return !ok || l.policy.allow(value, ent.Time)
+}
+
+// Sync flushes the policy if it holds back anything, such as the reports of a
+// throttler, then syncs the wrapped core.
+func (l *keyedLimiter) Sync() error {
+	if f, ok := l.policy.(interface{ flush(time.Time) }); ok {
+		f.flush(Clock().Now())
+	}
+	return l.Core.Sync()
+}
+
+// lookup returns the value of the first field with the limiter's key.
+func (l *keyedLimiter) lookup(fields []zapcore.Field) (string, bool) {
+	for _, field := range fields {
+		if field.Key == l.key {
+			return textValue(field), true
+		}
+	}
+	return "", false
+}
+
+// buckets are the leaky buckets of the values of a key.
+type buckets struct {
+	rate  float64
+	burst float64
+
+	mu     sync.Mutex
+	levels map[string]*bucket
+}
+
+type bucket struct {
+	level float64
+	last  time.Time
+}
+
+// allow reports whether an entry with value logged at now fits in its bucket,
+// adding it if so.
+func (b *buckets) allow(value string, now time.Time) bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	bkt, ok := b.levels[value]
+	if !ok {
+		if len(b.levels) >= maxBuckets {
+			b.evict(now)
+		}
+		bkt = &bucket{last: now}
+		b.levels[value] = bkt
+	}
+	if elapsed := now.Sub(bkt.last).Seconds(); elapsed > 0 {
+		bkt.level = max(0, bkt.level-elapsed*b.rate)
+		bkt.last = now
+	}
+	if bkt.level+1 > b.burst {
+		return false
+	}
+	bkt.level++
+	return true
+}
+
+// evict drops the buckets that have leaked empty by now, or all of them if
+// none has.
+func (b *buckets) evict(now time.Time) {
+	for value, bkt := range b.levels {
+		if bkt.level-now.Sub(bkt.last).Seconds()*b.rate <= 0 {
+			delete(b.levels, value)
+		}
+	}
+	if len(b.levels) >= maxBuckets {
+		b.levels = make(map[string]*bucket)
+	}
+}
+
+var _ zapcore.Core = (*keyedLimiter)(nil)
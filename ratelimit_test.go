@@ -0,0 +1,56 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRateLimiter(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewRateLimiter(core, traceIDKey, 1, 2))
+
+	noisy := Logger(Set(context.Background(), []zap.Field{zap.String(traceIDKey, "noisy")}), logger)
+	for i := 0; i < 5; i++ {
+		noisy.Info("attached")
+	}
+	for i := 0; i < 5; i++ {
+		logger.Info("inline", zap.String(traceIDKey, "inline"))
+	}
+	for i := 0; i < 3; i++ {
+		logger.Info("without key")
+	}
+	logger.Debug("disabled", zap.String(traceIDKey, "debug"))
+
+	assert.Equal(t, 2, logs.FilterMessage("attached").Len())
+	assert.Equal(t, 2, logs.FilterMessage("inline").Len())
+	assert.Equal(t, 3, logs.FilterMessage("without key").Len())
+}
+
+func TestRateLimiterKeepsTeeLevels(t *testing.T) {
+	core, infoLogs, errorLogs := newTeeCore()
+	logger := zap.New(NewRateLimiter(core, traceIDKey, 1, 2))
+
+	logger.Info("info", zap.String(traceIDKey, testTraceID))
+	logger.Error("error", zap.String(traceIDKey, testTraceID))
+	assert.Equal(t, 2, infoLogs.Len())
+	assert.Equal(t, 1, errorLogs.Len(), "the error-only core only gets the error")
+}
+
+func TestBuckets(t *testing.T) {
+	b := &buckets{rate: 2, burst: 1, levels: make(map[string]*bucket)}
+	now := time.Now()
+
+	assert.True(t, b.allow("a", now))
+	assert.False(t, b.allow("a", now))
+	assert.True(t, b.allow("b", now), "values have their own bucket")
+	assert.False(t, b.allow("a", now.Add(100*time.Millisecond)))
+	assert.True(t, b.allow("a", now.Add(600*time.Millisecond)), "buckets leak at rate")
+}
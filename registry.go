@@ -0,0 +1,96 @@
+package zax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// KeyInfo describes a key declared with [RegisterKey].
+type KeyInfo struct {
+	Name string
+	// Type is the type of the values of the key; zapcore.UnknownType allows
+	// any type.
+	Type        zapcore.FieldType
+	Description string
+}
+
+// ErrUnregisteredKey is reported by [RegisteredOnly] for keys that weren't
+// declared with [RegisterKey].
+var ErrUnregisteredKey = errors.New("unregistered key")
+
+var registry sync.Map // map[string]KeyInfo
+
+// RegisterKey declares an approved key, so that central teams can keep log
+// schemas consistent across services. Registering a key again with the same
+// type and description is a no-op; registering it with another type or
+// description fails.
+//
+// Registered keys can be enforced with the [RegisteredOnly] validator, or
+// reported with [WarnUnregistered].
+func RegisterKey(name string, typ zapcore.FieldType, description string) error {
+	info := KeyInfo{Name: name, Type: typ, Description: description}
+	if previous, loaded := registry.LoadOrStore(name, info); loaded && previous != info {
+		return fmt.Errorf("zax: key %q already registered as %+v", name, previous)
+	}
+	return nil
+}
+
+// MustRegisterKey is like [RegisterKey], but panics if registration fails.
+func MustRegisterKey(name string, typ zapcore.FieldType, description string) {
+	if err := RegisterKey(name, typ, description); err != nil {
+		panic(err)
+	}
+}
+
+// LookupKey returns the registered key with name, if any.
+func LookupKey(name string) (KeyInfo, bool) {
+	info, ok := registry.Load(name)
+	if !ok {
+		return KeyInfo{}, false
+	}
+	return info.(KeyInfo), true
+}
+
+// RegisteredKeys returns the registered keys, sorted by name.
+func RegisteredKeys() []KeyInfo {
+	var infos []KeyInfo
+	registry.Range(func(_, info any) bool {
+		infos = append(infos, info.(KeyInfo))
+		return true
+	})
+	sort.Slice(infos, func(i, j int) bool { return infos[i].Name < infos[j].Name })
+	return infos
+}
+
+// RegisteredOnly rejects keys that weren't declared with [RegisterKey]; see
+// [SetKeyValidator].
+func RegisteredOnly(key string) (string, error) {
+	if _, ok := registry.Load(key); !ok {
+		return key, ErrUnregisteredKey
+	}
+	return key, nil
+}
+
+// WarnUnregistered returns an option making Set and Append log a warning
+// through logger for every written field whose key isn't registered, or whose
+// type differs from the registered one, without rejecting it.
+func WarnUnregistered(logger *zap.Logger) Option {
+	hook := func(_ context.Context, event WriteEvent) {
+		for _, field := range event.Fields {
+			info, ok := LookupKey(field.Key)
+			switch {
+			case !ok:
+				logger.Warn("zax: unregistered key", zap.String("key", field.Key), zap.String("caller", event.Caller.Function))
+			case info.Type != zapcore.UnknownType && info.Type != field.Type:
+				logger.Warn("zax: registered key written with another type", zap.String("key", field.Key), zap.String("caller", event.Caller.Function))
+			}
+		}
+	}
+	return Options(WithSetHook(hook), WithAppendHook(hook))
+}
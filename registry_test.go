@@ -0,0 +1,46 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestRegisterKey(t *testing.T) {
+	require.NoError(t, RegisterKey("registry_test_order", zapcore.StringType, "The ID of the order."))
+	require.NoError(t, RegisterKey("registry_test_order", zapcore.StringType, "The ID of the order."))
+	assert.Error(t, RegisterKey("registry_test_order", zapcore.Int64Type, "The ID of the order."))
+	assert.Panics(t, func() { MustRegisterKey("registry_test_order", zapcore.StringType, "Another description.") })
+
+	info, ok := LookupKey("registry_test_order")
+	assert.True(t, ok)
+	assert.Equal(t, KeyInfo{Name: "registry_test_order", Type: zapcore.StringType, Description: "The ID of the order."}, info)
+	assert.Contains(t, RegisteredKeys(), info)
+
+	_, err := RegisteredOnly("registry_test_order")
+	assert.NoError(t, err)
+	_, err = RegisteredOnly("registry_test_unknown")
+	assert.ErrorIs(t, err, ErrUnregisteredKey)
+}
+
+func TestWarnUnregistered(t *testing.T) {
+	MustRegisterKey("registry_test_amount", zapcore.Int64Type, "The amount, in cents.")
+	logs := newTestLogger(t)
+	store := NewStore(WarnUnregistered(logs.GetZapLogger()))
+
+	ctx := store.Set(context.Background(), []zap.Field{zap.Int64("registry_test_amount", 100), zap.String("registry_test_unknown", "value")})
+	store.Append(ctx, []zap.Field{zap.String("registry_test_amount", "100")})
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "zax: unregistered key", entries[0].Message)
+	assert.Equal(t, "registry_test_unknown", entries[0].ContextMap()["key"])
+	assert.Equal(t, "zax: registered key written with another type", entries[1].Message)
+	assert.Contains(t, entries[1].ContextMap()["caller"], "TestWarnUnregistered")
+}
@@ -0,0 +1,45 @@
+package zax
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// MissingFieldsError is the error reported by [RequireFields] when required
+// fields are not in the context.
+type MissingFieldsError struct {
+	Keys []string
+}
+
+func (e *MissingFieldsError) Error() string {
+	return fmt.Sprintf("zax: missing required fields: %s", strings.Join(e.Keys, ", "))
+}
+
+// RequireFields returns the fields stored in ctx with keys, along with a
+// [*MissingFieldsError] if any is missing, so that handlers can fail fast when
+// mandatory correlation fields were never established. Unlike [GetFields], it
+// never includes an [AbsentFieldsKey] field.
+func RequireFields(ctx context.Context, keys ...string) ([]zap.Field, error) {
+	return defaultStore.RequireFields(ctx, keys...)
+}
+
+// RequireFields returns the fields of s in ctx with keys, or an error if any
+// is missing; see [RequireFields].
+func (s *Store) RequireFields(ctx context.Context, keys ...string) ([]zap.Field, error) {
+	fields := make([]zap.Field, 0, len(keys))
+	var missing []string
+	for _, key := range keys {
+		if field, ok := s.GetField(ctx, key); ok {
+			fields = append(fields, field)
+		} else {
+			missing = append(missing, key)
+		}
+	}
+	if missing != nil {
+		return fields, &MissingFieldsError{Keys: missing}
+	}
+	return fields, nil
+}
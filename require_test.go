@@ -0,0 +1,27 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestRequireFields(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	fields, err := RequireFields(ctx, traceIDKey)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, fields)
+
+	fields, err = RequireFields(ctx, traceIDKey, spanIDKey, "user_id")
+	var missing *MissingFieldsError
+	require.ErrorAs(t, err, &missing)
+	assert.Equal(t, []string{spanIDKey, "user_id"}, missing.Keys)
+	assert.EqualError(t, err, "zax: missing required fields: span_id, user_id")
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, fields)
+}
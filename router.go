@@ -0,0 +1,126 @@
+package zax
+
+import (
+	"go.uber.org/multierr"
+	"go.uber.org/zap/zapcore"
+)
+
+// Route sends the entries whose field with Key holds Value to Core.
+type Route struct {
+	Key   string
+	Value string
+	Core  zapcore.Core
+}
+
+// NewRouter returns a core dispatching entries to cores based on field
+// values: every entry goes to the core of each matching route, or to fallback
+// if no route matches. A nil fallback drops unmatched entries. For example,
+// to send the entries of a tenant and audit entries to dedicated sinks:
+//
+//	core = zax.NewRouter(core,
+//		zax.Route{Key: "tenant_id", Value: "acme", Core: acmeCore},
+//		zax.Route{Key: "audit", Value: "true", Core: auditCore},
+//	)
+//
+// Like [NewTraceSampler], values are looked up in the fields attached with
+// [Logger] or [zap.Logger.With] as well as in the fields of each entry, and
+// compared in their string representation.
+func NewRouter(fallback zapcore.Core, routes ...Route) zapcore.Core {
+	if fallback == nil {
+		fallback = zapcore.NewNopCore()
+	}
+	return &router{fallback: fallback, routes: routes}
+}
+
+type router struct {
+	fallback zapcore.Core
+	routes   []Route
+	// attached holds the values of the route keys in the attached fields.
+	attached map[string]string
+}
+
+func (r *router) Enabled(level zapcore.Level) bool {
+	if r.fallback.Enabled(level) {
+		return true
+	}
+	for _, route := range r.routes {
+		if route.Core.Enabled(level) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *router) With(fields []zapcore.Field) zapcore.Core {
+	clone := &router{
+		fallback: r.fallback.With(fields),
+		routes:   make([]Route, len(r.routes)),
+		attached: r.values(fields),
+	}
+	for i, route := range r.routes {
+		route.Core = route.Core.With(fields)
+		clone.routes[i] = route
+	}
+	return clone
+}
+
+func (r *router) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	// The routes depend on the fields of the entry, only known by Write.
+	if r.Enabled(ent.Level) {
+		return ce.AddCore(ent, r)
+	}
+	return ce
+}
+
+func (r *router) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	values := r.values(fields)
+	var err error
+	matched := false
+	for _, route := range r.routes {
+		if value, ok := values[route.Key]; ok && value == route.Value {
+			matched = true
+			err = multierr.Append(err, write(route.Core, ent, fields))
+		}
+	}
+	if !matched {
+		err = multierr.Append(err, write(r.fallback, ent, fields))
+	}
+	return err
+}
+
+func (r *router) Sync() error {
+	err := r.fallback.Sync()
+	for _, route := range r.routes {
+		err = multierr.Append(err, route.Core.Sync())
+	}
+	return err
+}
+
+// values returns the attached values of the route keys, updated by the first
+// field of fields with each key.
+func (r *router) values(fields []zapcore.Field) map[string]string {
+	values := make(map[string]string, len(r.attached))
+	for key, value := range r.attached {
+		values[key] = value
+	}
+	seen := make(map[string]bool)
+	for _, field := range fields {
+		if seen[field.Key] {
+			continue
+		}
+		for _, route := range r.routes {
+			if route.Key == field.Key {
+				values[field.Key] = textValue(field)
+				seen[field.Key] = true
+				break
+			}
+		}
+	}
+	return values
+}
+
+// write writes an entry to core, if core accepts it, so that the filtering
+// route cores do in Check, such as sampling, applies.
+func write(core zapcore.Core, ent zapcore.Entry, fields []zapcore.Field) error {
+	return writeChecked(core.Check(ent, nil), ent, fields)
+}
@@ -0,0 +1,67 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestRouter(t *testing.T) {
+	fallbackCore, fallback := observer.New(zapcore.InfoLevel)
+	acmeCore, acme := observer.New(zapcore.InfoLevel)
+	auditCore, audit := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewRouter(fallbackCore,
+		Route{Key: "tenant_id", Value: "acme", Core: acmeCore},
+		Route{Key: "audit", Value: "true", Core: auditCore},
+	))
+
+	acmeCtx := Set(context.Background(), []zap.Field{zap.String("tenant_id", "acme")})
+	Logger(acmeCtx, logger).Info("acme")
+	Logger(acmeCtx, logger).Info("acme audit", zap.Bool("audit", true))
+	Logger(acmeCtx, logger).Info("overridden", zap.String("tenant_id", "other"))
+	Logger(Set(context.Background(), []zap.Field{zap.String("tenant_id", "other")}), logger).Info("other")
+	logger.Info("audit", zap.Bool("audit", true))
+	logger.Debug("disabled", zap.Bool("audit", true))
+
+	messages := func(logs *observer.ObservedLogs) []string {
+		var messages []string
+		for _, entry := range logs.All() {
+			messages = append(messages, entry.Message)
+		}
+		return messages
+	}
+	assert.Equal(t, []string{"acme", "acme audit"}, messages(acme))
+	assert.Equal(t, []string{"acme audit", "audit"}, messages(audit))
+	assert.Equal(t, []string{"overridden", "other"}, messages(fallback))
+	assert.Equal(t, "acme", acme.All()[0].ContextMap()["tenant_id"], "attached fields reach the routed cores")
+}
+
+func TestRouterWithoutFallback(t *testing.T) {
+	auditCore, audit := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewRouter(nil, Route{Key: "audit", Value: "true", Core: auditCore}))
+
+	logger.Info("dropped")
+	logger.With(zap.Bool("audit", true)).Info("audit")
+	assert.Equal(t, 1, audit.Len())
+	assert.NoError(t, logger.Sync())
+}
+
+func TestRouterKeepsRouteFiltering(t *testing.T) {
+	teeCore, infoLogs, errorLogs := newTeeCore()
+	sampledLogger, sampled := newSampledLogger(zapcore.InfoLevel)
+	logger := zap.New(NewRouter(sampledLogger.Core(), Route{Key: "audit", Value: "true", Core: teeCore}))
+
+	logger.Info("audit", zap.Bool("audit", true))
+	for i := 0; i < 3; i++ {
+		logger.Info("sampled")
+	}
+	assert.Equal(t, 1, infoLogs.Len())
+	assert.Zero(t, errorLogs.Len(), "the error-only core doesn't get the info entry")
+	assert.Equal(t, 1, sampled.Len(), "the fallback keeps sampling")
+}
@@ -0,0 +1,165 @@
+package zax
+
+import (
+	"hash/fnv"
+	"math"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// NewTraceSampler returns a core that samples whole traces instead of single
+// entries: every entry carrying the same value for key, typically a trace ID,
+// gets the same keep or drop decision, so sampled requests keep all their
+// lines. A deterministic rate fraction of the values is kept, consistently
+// across processes. Entries without key are always kept.
+//
+// The value is looked up in the fields attached with [Logger] or
+// [zap.Logger.With] as well as in the fields of each entry:
+//
+//	logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+//		return zax.NewTraceSampler(core, "trace_id", 0.1)
+//	}))
+//	zax.Logger(ctx, logger).Info("kept or dropped along with the whole trace")
+func NewTraceSampler(core zapcore.Core, key string, rate float64) zapcore.Core {
+	return &traceSampler{Core: core, key: key, threshold: sampleThreshold(rate)}
+}
+
+// NewHashSampler is like [NewTraceSampler], but always keeps the entries
+// carrying one of the values of allowlist. It suits sampling on values such
+// as user IDs, keeping 1% of the users along with every line of a few
+// accounts under investigation:
+//
+//	zax.NewHashSampler(core, "user_id", 0.01, "u-42")
+//
+// See [WithHashSampling] to apply the decision when deriving loggers from
+// contexts instead.
+func NewHashSampler(core zapcore.Core, key string, rate float64, allowlist ...string) zapcore.Core {
+	return &traceSampler{Core: core, key: key, threshold: sampleThreshold(rate), allow: allowSet(allowlist)}
+}
+
+type traceSampler struct {
+	zapcore.Core
+	key       string
+	threshold uint64
+	allow     map[string]bool
+	// decided tells whether the attached fields carry key, in which case keep
+	// holds the decision.
+	decided bool
+	keep    bool
+}
+
+func (s *traceSampler) With(fields []zapcore.Field) zapcore.Core {
+	clone := *s
+	clone.Core = s.Core.With(fields)
+	if keep, ok := s.decide(fields); ok {
+		clone.decided, clone.keep = true, keep
+	}
+	return &clone
+}
+
+func (s *traceSampler) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if s.decided {
+		if !s.keep {
+			return ce
+		}
+		return s.Core.Check(ent, ce)
+	}
+	// The decision depends on the fields of the entry, only known by Write.
+	return filterThrough(s.Core, ent, ce, s.keeps)
+}
+
+// keeps reports whether the entry with fields is kept.
+func (s *traceSampler) keeps(_ zapcore.Entry, fields []zapcore.Field) bool {
+	keep, ok := s.decide(fields)
+	return keep || !ok
+}
+
+func (s *traceSampler) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	if !s.keeps(ent, fields) {
+		return nil
+	}
+	return s.Core.Write(ent, fields)
+}
+
+// decide returns the decision for the first field with the sampler's key.
+func (s *traceSampler) decide(fields []zapcore.Field) (keep bool, ok bool) {
+	for _, field := range fields {
+		if field.Key == s.key {
+			value := textValue(field)
+			return s.allow[value] || sampled(value, s.threshold), true
+		}
+	}
+	return false, false
+}
+
+// WithHashSampling makes [Logger] sample contexts on the value of key, like
+// [NewHashSampler] samples entries: for contexts whose value of key is neither
+// sampled nor in allowlist, Logger returns a no-op logger, so that their
+// entries are dropped without even being encoded. Contexts without key are
+// always logged. Defaults count as values; key is looked up before mapping.
+func WithHashSampling(key string, rate float64, allowlist ...string) Option {
+	sampling := &hashSampling{key: key, threshold: sampleThreshold(rate), allow: allowSet(allowlist)}
+	return func(c *config) {
+		c.sampling = sampling
+	}
+}
+
+// hashSampling is the configuration set by [WithHashSampling].
+type hashSampling struct {
+	key       string
+	threshold uint64
+	allow     map[string]bool
+}
+
+// keep reports whether the fields of c, with defaults, are sampled.
+func (h *hashSampling) keep(cfg *config, c *container) bool {
+	var fields []zapcore.Field
+	if c != nil {
+		fields = c.fields
+	}
+	for _, fields := range [2][]zapcore.Field{fields, cfg.defaults} {
+		for _, field := range fields {
+			if field.Key == h.key {
+				value := textValue(field)
+				return h.allow[value] || sampled(value, h.threshold)
+			}
+		}
+	}
+	return true
+}
+
+// allowSet returns the set of values, or nil if there are none.
+func allowSet(values []string) map[string]bool {
+	if len(values) == 0 {
+		return nil
+	}
+	set := make(map[string]bool, len(values))
+	for _, value := range values {
+		set[value] = true
+	}
+	return set
+}
+
+// sampleThreshold converts a rate into the hash value below which values are
+// sampled.
+func sampleThreshold(rate float64) uint64 {
+	switch {
+	case rate <= 0:
+		return 0
+	case rate >= 1:
+		return math.MaxUint64
+	}
+	return uint64(rate * math.MaxUint64)
+}
+
+// sampled reports whether value hashes below threshold.
+func sampled(value string, threshold uint64) bool {
+	if threshold == math.MaxUint64 {
+		return true
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(value))
+	return h.Sum64() < threshold
+}
+
+var _ zapcore.Core = (*traceSampler)(nil)
@@ -0,0 +1,117 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// sampledTraceID returns a trace ID that is, or isn't, sampled at rate.
+func sampledTraceID(rate float64, want bool) string {
+	threshold := sampleThreshold(rate)
+	for i := 0; ; i++ {
+		traceID := fmt.Sprintf("trace-%d", i)
+		if sampled(traceID, threshold) == want {
+			return traceID
+		}
+	}
+}
+
+func TestTraceSampler(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewTraceSampler(core, traceIDKey, 0.5))
+
+	kept := Set(context.Background(), []zap.Field{zap.String(traceIDKey, sampledTraceID(0.5, true))})
+	dropped := Set(context.Background(), []zap.Field{zap.String(traceIDKey, sampledTraceID(0.5, false))})
+
+	for i := 0; i < 3; i++ {
+		Logger(kept, logger).Info("kept")
+		Logger(dropped, logger).Info("dropped")
+	}
+	logger.Info("kept", zap.String(traceIDKey, sampledTraceID(0.5, true)))
+	logger.Info("dropped", zap.String(traceIDKey, sampledTraceID(0.5, false)))
+	logger.Info("kept without trace")
+
+	assert.Equal(t, 5, recorded.Len())
+	assert.Equal(t, 4, recorded.FilterMessage("kept").Len())
+	assert.Equal(t, 1, recorded.FilterMessage("kept without trace").Len())
+}
+
+func TestTraceSamplerRates(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	never := zap.New(NewTraceSampler(core, traceIDKey, 0))
+	always := zap.New(NewTraceSampler(core, traceIDKey, 1))
+
+	for i := 0; i < 100; i++ {
+		never.Info("never", zap.Int(traceIDKey, i))
+		always.Info("always", zap.Int(traceIDKey, i))
+	}
+	assert.Equal(t, 0, recorded.FilterMessage("never").Len())
+	assert.Equal(t, 100, recorded.FilterMessage("always").Len())
+}
+
+func TestTraceSamplerRespectsLevel(t *testing.T) {
+	core, recorded := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewTraceSampler(core, traceIDKey, 1))
+
+	logger.Debug("filtered")
+	logger.With(zap.String(traceIDKey, testTraceID)).Debug("filtered")
+	assert.Zero(t, recorded.Len())
+}
+
+func TestTraceSamplerKeepsTeeLevels(t *testing.T) {
+	core, infoLogs, errorLogs := newTeeCore()
+	logger := zap.New(NewTraceSampler(core, traceIDKey, 1))
+
+	logger.Info("info", zap.String(traceIDKey, testTraceID))
+	logger.Error("error", zap.String(traceIDKey, testTraceID))
+	assert.Equal(t, 2, infoLogs.Len())
+	assert.Equal(t, 1, errorLogs.Len(), "the error-only core only gets the error")
+}
+
+func TestHashSampler(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := zap.New(NewHashSampler(core, "user_id", 0, "u-42"))
+
+	Logger(Set(context.Background(), []zap.Field{zap.String("user_id", "u-42")}), logger).Info("allowed")
+	Logger(Set(context.Background(), []zap.Field{zap.String("user_id", "u-7")}), logger).Info("dropped")
+	logger.Info("allowed", zap.String("user_id", "u-42"))
+	logger.Info("dropped", zap.String("user_id", "u-7"))
+
+	assert.Equal(t, 2, recorded.Len())
+	assert.Equal(t, 2, recorded.FilterMessage("allowed").Len())
+}
+
+func TestHashSamplerKeepsTeeLevels(t *testing.T) {
+	core, infoLogs, errorLogs := newTeeCore()
+	logger := zap.New(NewHashSampler(core, "user_id", 0, "u-42"))
+
+	logger.Info("allowed", zap.String("user_id", "u-42"))
+	logger.Error("dropped", zap.String("user_id", "u-7"))
+	assert.Equal(t, 1, infoLogs.Len())
+	assert.Zero(t, errorLogs.Len(), "the error-only core gets neither entry")
+}
+
+func TestWithHashSampling(t *testing.T) {
+	core, recorded := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+	store := NewStore(WithHashSampling(traceIDKey, 0.5, "allowed-trace"))
+
+	for _, traceID := range []string{sampledTraceID(0.5, true), sampledTraceID(0.5, false), "allowed-trace"} {
+		ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, traceID)})
+		store.Logger(ctx, logger).Info(traceID)
+	}
+	store.Logger(context.Background(), logger).Info("without trace")
+
+	assert.Equal(t, 3, recorded.Len())
+	assert.Equal(t, 0, recorded.FilterMessage(sampledTraceID(0.5, false)).Len())
+	assert.False(t, sampled("allowed-trace", sampleThreshold(0.5)))
+	assert.Equal(t, 1, recorded.FilterMessage("allowed-trace").Len(), "allowlisted values are kept")
+}
@@ -0,0 +1,131 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// Scope is a named set of fields within a store, which can be set, read and
+// cleared independently of the store's other fields: e.g. "request", "tenant"
+// and "job" scopes, each maintained by a different layer.
+//
+// [GetAll] returns the unscoped fields first, followed by the fields of each
+// scope in the order the scopes were created. Lookups such as [GetField]
+// return the first match, so create scopes from the highest precedence to the
+// lowest, e.g. application scopes before infrastructure ones.
+type Scope struct {
+	store *Store
+	name  string
+	rank  int64
+}
+
+// scopeFields are the fields stored in a scope.
+type scopeFields struct {
+	scope  *Scope
+	fields []zap.Field
+}
+
+// NewScope returns a new scope of the default store.
+func NewScope(name string) *Scope {
+	return defaultStore.NewScope(name)
+}
+
+// NewScope returns a new scope of s, ranked after the ones created before.
+func (s *Store) NewScope(name string) *Scope {
+	return &Scope{store: s, name: name, rank: s.scopes.Add(1)}
+}
+
+// Name returns the name of the scope.
+func (sc *Scope) Name() string {
+	return sc.name
+}
+
+// Set replaces the fields of the scope in ctx with fields.
+func (sc *Scope) Set(ctx context.Context, fields []zap.Field) context.Context {
+	ctx, _ = sc.store.write(ctx, sc, fields, opSet, false)
+	return ctx
+}
+
+// Append adds fields to the fields of the scope in ctx.
+func (sc *Scope) Append(ctx context.Context, fields []zap.Field) context.Context {
+	ctx, _ = sc.store.write(ctx, sc, fields, opAppend, false)
+	return ctx
+}
+
+// Get returns a copy of the fields of the scope in ctx, which callers are
+// free to modify.
+func (sc *Scope) Get(ctx context.Context) []zap.Field {
+	return concatFields(sc.store.fromContext(ctx).part(sc), nil)
+}
+
+// Clear removes the fields of the scope from ctx, leaving other fields as
+// they are.
+func (sc *Scope) Clear(ctx context.Context) context.Context {
+	c := sc.store.fromContext(ctx)
+	if len(c.part(sc)) == 0 {
+		return ctx
+	}
+	return context.WithValue(ctx, sc.store.key, c.with(sc, nil))
+}
+
+// part returns the fields of scope, or the unscoped fields if scope is nil.
+func (c *container) part(scope *Scope) []zap.Field {
+	if c == nil {
+		return nil
+	}
+	if scope == nil {
+		return c.unscoped
+	}
+	for _, s := range c.scopes {
+		if s.scope == scope {
+			return s.fields
+		}
+	}
+	return nil
+}
+
+// with returns a new container with the fields of scope, or the unscoped
+// fields if scope is nil, replaced by fields.
+func (c *container) with(scope *Scope, fields []zap.Field) *container {
+	next := &container{}
+	if c != nil {
+		next.unscoped = c.unscoped
+		next.scopes = c.scopes
+	}
+	if scope == nil {
+		next.unscoped = fields
+	} else {
+		next.scopes = replaceScope(next.scopes, scope, fields)
+	}
+	next.fields = next.unscoped
+	if len(next.scopes) > 0 {
+		next.fields = make([]zap.Field, 0, len(next.unscoped)+len(next.scopes)*2)
+		next.fields = append(next.fields, next.unscoped...)
+		for _, s := range next.scopes {
+			next.fields = append(next.fields, s.fields...)
+		}
+	}
+	return next
+}
+
+// replaceScope returns a copy of scopes with the fields of scope replaced,
+// keeping scopes ordered by rank and leaving out empty ones.
+func replaceScope(scopes []scopeFields, scope *Scope, fields []zap.Field) []scopeFields {
+	replaced := make([]scopeFields, 0, len(scopes)+1)
+	inserted := len(fields) == 0
+	for _, s := range scopes {
+		if s.scope == scope {
+			continue
+		}
+		if !inserted && s.scope.rank > scope.rank {
+			replaced = append(replaced, scopeFields{scope: scope, fields: fields})
+			inserted = true
+		}
+		replaced = append(replaced, s)
+	}
+	if !inserted {
+		replaced = append(replaced, scopeFields{scope: scope, fields: fields})
+	}
+	return replaced
+}
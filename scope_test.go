@@ -0,0 +1,65 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestScopes(t *testing.T) {
+	store := NewStore()
+	request := store.NewScope("request")
+	tenant := store.NewScope("tenant")
+	assert.Equal(t, "tenant", tenant.Name())
+
+	ctx := tenant.Set(context.Background(), []zap.Field{zap.String("tenant_id", "acme"), zap.String("env", "infra")})
+	ctx = store.Set(ctx, []zap.Field{zap.String("env", "app")})
+	ctx = request.Append(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	assert.Equal(t, []zap.Field{
+		zap.String("env", "app"),
+		zap.String(traceIDKey, testTraceID),
+		zap.String("tenant_id", "acme"),
+		zap.String("env", "infra"),
+	}, store.GetAll(ctx))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, request.Get(ctx))
+
+	env, _ := store.GetField(ctx, "env")
+	assert.Equal(t, "app", env.String, "unscoped fields take precedence")
+
+	cleared := request.Clear(ctx)
+	assert.Empty(t, request.Get(cleared))
+	assert.Equal(t, tenant.Get(ctx), tenant.Get(cleared))
+	assert.Equal(t, store.GetAll(ctx)[:1], store.GetAll(cleared)[:1])
+	assert.Len(t, store.GetAll(cleared), 3)
+	assert.Equal(t, cleared, request.Clear(cleared))
+
+	replaced := store.Set(ctx, nil)
+	assert.Equal(t, []zap.Field{
+		zap.String(traceIDKey, testTraceID),
+		zap.String("tenant_id", "acme"),
+		zap.String("env", "infra"),
+	}, store.GetAll(replaced), "Set replaces the unscoped fields only")
+}
+
+func TestDefaultStoreScope(t *testing.T) {
+	job := NewScope("job")
+	ctx := job.Set(context.Background(), []zap.Field{zap.String("job_id", "42")})
+	ctx = Append(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID), zap.String("job_id", "42")}, GetAll(ctx))
+}
+
+func TestScopeGetCopies(t *testing.T) {
+	store := NewStore()
+	tenant := store.NewScope("tenant")
+	ctx := tenant.Set(context.Background(), []zap.Field{zap.String("tenant_id", "acme")})
+
+	tenant.Get(ctx)[0] = zap.String("tenant_id", "tampered")
+	assert.Equal(t, []zap.Field{zap.String("tenant_id", "acme")}, tenant.Get(ctx))
+	assert.Equal(t, []zap.Field{zap.String("tenant_id", "acme")}, store.GetAll(ctx))
+}
@@ -0,0 +1,139 @@
+package zax
+
+import (
+	"expvar"
+	"sort"
+	"sync"
+	"sync/atomic"
+
+	"go.uber.org/zap"
+)
+
+const (
+	// maxTrackedKeys bounds the number of distinct keys counted by stats, so
+	// that keys derived from input can't grow memory without bound.
+	maxTrackedKeys = 1 << 10
+	// topKeys is the number of keys reported in [Stats.TopKeys].
+	topKeys = 10
+)
+
+// Stats summarizes the writes to a [Store] since stats were enabled with
+// [WithStats], to help size the performance impact of context fields.
+type Stats struct {
+	// Sets and Appends count the writes by operation.
+	Sets    uint64
+	Appends uint64
+	// Prunes counts the calls to Prune.
+	Prunes uint64
+	// AvgFields is the average number of fields in the context after a write.
+	AvgFields float64
+	// TopKeys are the most written keys, most written first.
+	TopKeys []KeyCount
+}
+
+// KeyCount is the number of writes of a key.
+type KeyCount struct {
+	Key   string
+	Count uint64
+}
+
+// WithStats enables or disables collecting [Stats]. Enabling it resets the
+// collected stats.
+func WithStats(enabled bool) Option {
+	return func(c *config) {
+		c.stats = nil
+		if enabled {
+			c.stats = &statsCollector{}
+		}
+	}
+}
+
+// statsCollector counts writes; it is shared by the configs derived from the
+// one enabling it.
+type statsCollector struct {
+	sets    atomic.Uint64
+	appends atomic.Uint64
+	prunes  atomic.Uint64
+	fields  atomic.Uint64
+
+	mu   sync.Mutex
+	keys map[string]uint64
+}
+
+// record counts a write of fields by op, leaving total fields in the context.
+func (s *statsCollector) record(op writeOp, fields []zap.Field, total int) {
+	if s == nil {
+		return
+	}
+	if op == opAppend {
+		s.appends.Add(1)
+	} else {
+		s.sets.Add(1)
+	}
+	s.fields.Add(uint64(total))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.keys == nil {
+		s.keys = make(map[string]uint64)
+	}
+	for _, field := range fields {
+		if _, ok := s.keys[field.Key]; ok || len(s.keys) < maxTrackedKeys {
+			s.keys[field.Key]++
+		}
+	}
+}
+
+// recordPrune counts a prune.
+func (s *statsCollector) recordPrune() {
+	if s != nil {
+		s.prunes.Add(1)
+	}
+}
+
+// snapshot returns the current stats.
+func (s *statsCollector) snapshot() Stats {
+	if s == nil {
+		return Stats{}
+	}
+	stats := Stats{Sets: s.sets.Load(), Appends: s.appends.Load(), Prunes: s.prunes.Load()}
+	if writes := stats.Sets + stats.Appends; writes > 0 {
+		stats.AvgFields = float64(s.fields.Load()) / float64(writes)
+	}
+
+	s.mu.Lock()
+	for key, count := range s.keys {
+		stats.TopKeys = append(stats.TopKeys, KeyCount{Key: key, Count: count})
+	}
+	s.mu.Unlock()
+	sort.Slice(stats.TopKeys, func(i, j int) bool {
+		a, b := stats.TopKeys[i], stats.TopKeys[j]
+		return a.Count > b.Count || a.Count == b.Count && a.Key < b.Key
+	})
+	if len(stats.TopKeys) > topKeys {
+		stats.TopKeys = stats.TopKeys[:topKeys]
+	}
+	return stats
+}
+
+// GetStats returns the stats of the default store; they are empty unless
+// enabled with [WithStats].
+func GetStats() Stats {
+	return defaultStore.Stats()
+}
+
+// Stats returns the stats of s; see [GetStats].
+func (s *Store) Stats() Stats {
+	return s.loadConfig().stats.snapshot()
+}
+
+// PublishStats exports the stats of the default store as the expvar variable
+// name. Like [expvar.Publish], it panics if name is already published.
+func PublishStats(name string) {
+	defaultStore.PublishStats(name)
+}
+
+// PublishStats exports the stats of s; see [PublishStats].
+func (s *Store) PublishStats(name string) {
+	expvar.Publish(name, expvar.Func(func() any { return s.Stats() }))
+}
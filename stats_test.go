@@ -0,0 +1,49 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"encoding/json"
+	"expvar"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestStats(t *testing.T) {
+	store := NewStore()
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	assert.Equal(t, Stats{}, store.Stats(), "stats are disabled by default")
+
+	store.Configure(WithStats(true))
+	ctx = store.Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
+	ctx = store.Append(ctx, []zap.Field{zap.String(spanIDKey, "span"), zap.Int("attempt", 1)})
+	store.Append(ctx, []zap.Field{zap.String(spanIDKey, "span")})
+
+	assert.Equal(t, Stats{
+		Sets:      1,
+		Appends:   2,
+		AvgFields: 8.0 / 3,
+		TopKeys: []KeyCount{
+			{Key: spanIDKey, Count: 2},
+			{Key: "attempt", Count: 1},
+			{Key: traceIDKey, Count: 1},
+		},
+	}, store.Stats())
+
+	store.Configure(WithStats(false))
+	assert.Equal(t, Stats{}, store.Stats())
+}
+
+func TestPublishStats(t *testing.T) {
+	store := NewStore(WithStats(true))
+	store.PublishStats("zax_test_stats")
+	store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	var stats Stats
+	require.NoError(t, json.Unmarshal([]byte(expvar.Get("zax_test_stats").String()), &stats))
+	assert.Equal(t, store.Stats(), stats)
+}
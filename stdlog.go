@@ -0,0 +1,69 @@
+package zax
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"log"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// stdLogCallerSkip skips the frames of a standard logger between its caller
+// and the Write method of its writer.
+const stdLogCallerSkip = 2
+
+// StdLogger returns a standard library logger whose output is logged at
+// level through logger, with the fields stored in ctx attached as by
+// [Logger], for third-party libraries that only accept a [*log.Logger]. Each
+// line of its output is logged as one entry, reported at the call site of
+// the standard logger.
+func StdLogger(ctx context.Context, logger *zap.Logger, level zapcore.Level) *log.Logger {
+	return defaultStore.StdLogger(ctx, logger, level)
+}
+
+// StdLogger returns a standard library logger with the fields of s in ctx;
+// see [StdLogger].
+func (s *Store) StdLogger(ctx context.Context, logger *zap.Logger, level zapcore.Level) *log.Logger {
+	return log.New(s.writer(ctx, logger, level, stdLogCallerSkip), "", 0)
+}
+
+// Writer returns a writer logging each line written to it at level through
+// logger, with the fields stored in ctx attached as by [Logger]. Empty lines
+// are dropped.
+func Writer(ctx context.Context, logger *zap.Logger, level zapcore.Level) io.Writer {
+	return defaultStore.Writer(ctx, logger, level)
+}
+
+// Writer returns a writer logging with the fields of s in ctx; see [Writer].
+func (s *Store) Writer(ctx context.Context, logger *zap.Logger, level zapcore.Level) io.Writer {
+	return s.writer(ctx, logger, level, 0)
+}
+
+func (s *Store) writer(ctx context.Context, logger *zap.Logger, level zapcore.Level, skip int) *lineWriter {
+	return &lineWriter{
+		logger: s.Logger(ctx, orDefault(logger)).WithOptions(zap.AddCallerSkip(1 + skip)),
+		level:  level,
+	}
+}
+
+// lineWriter logs each line written to it.
+type lineWriter struct {
+	logger *zap.Logger
+	level  zapcore.Level
+}
+
+func (w *lineWriter) Write(p []byte) (int, error) {
+	for rest := p; len(rest) > 0; {
+		var line []byte
+		line, rest, _ = bytes.Cut(rest, []byte{'\n'})
+		if len(line) == 0 {
+			continue
+		}
+		if ce := w.logger.Check(w.level, string(line)); ce != nil {
+			ce.Write()
+		}
+	}
+	return len(p), nil
+}
@@ -0,0 +1,46 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestStdLogger(t *testing.T) {
+	logs := newTestLogger(t)
+	logger := logs.GetZapLogger().WithOptions(zap.AddCaller())
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	StdLogger(ctx, logger, zapcore.WarnLevel).Printf("from %s", "library")
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	assert.Equal(t, zapcore.WarnLevel, entries[0].Level)
+	assert.Equal(t, "from library", entries[0].Message)
+	assert.Equal(t, testTraceID, entries[0].ContextMap()[traceIDKey])
+	assert.Equal(t, "stdlog_test.go", filepath.Base(entries[0].Caller.File))
+}
+
+func TestWriter(t *testing.T) {
+	logs := newTestLogger(t)
+	logger := logs.GetZapLogger().WithOptions(zap.AddCaller())
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	n, err := Writer(ctx, logger, zapcore.InfoLevel).Write([]byte("first\n\nsecond"))
+	require.NoError(t, err)
+	assert.Equal(t, len("first\n\nsecond"), n)
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "first", entries[0].Message)
+	assert.Equal(t, "second", entries[1].Message)
+	assert.Equal(t, testTraceID, entries[1].ContextMap()[traceIDKey])
+	assert.Equal(t, "stdlog_test.go", filepath.Base(entries[0].Caller.File))
+}
@@ -0,0 +1,34 @@
+package zax
+
+import (
+	"context"
+	"sync/atomic"
+)
+
+// Store is an independent set of fields in a context. Each store keeps its
+// fields under its own private context key, with its own configuration, so
+// libraries that need isolation (or vendor different copies of zax) don't
+// overwrite each other's fields.
+//
+// The package-level functions operate on a default store.
+type Store struct {
+	key    any
+	config atomic.Pointer[config]
+	scopes atomic.Int64
+}
+
+// storeKey is the context key of a store created with NewStore. It isn't
+// zero-sized, so that every allocated key is distinct.
+type storeKey struct {
+	_ byte
+}
+
+var defaultStore = &Store{key: loggerKey}
+
+func (s *Store) fromContext(ctx context.Context) *container {
+	if disabled {
+		return nil
+	}
+	c, _ := ctx.Value(s.key).(*container)
+	return c
+}
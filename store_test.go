@@ -0,0 +1,64 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestStoreIsolation(t *testing.T) {
+	first, second := NewStore(), NewStore()
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, "default")})
+	ctx = first.Set(ctx, []zap.Field{zap.String(traceIDKey, "first")})
+	ctx = second.Append(ctx, []zap.Field{zap.String(traceIDKey, "second")})
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, "default")}, GetAll(ctx))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, "first")}, first.GetAll(ctx))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, "second")}, second.GetAll(ctx))
+
+	field, ok := first.GetField(ctx, traceIDKey)
+	assert.True(t, ok)
+	assert.Equal(t, "first", field.String)
+	assert.Len(t, second.GetFields(ctx, traceIDKey, spanIDKey), 2)
+}
+
+func TestStoreConfigIsolation(t *testing.T) {
+	SetLimits(Limits{MaxFields: 1})
+	t.Cleanup(func() { SetLimits(Limits{}) })
+
+	fields := []zap.Field{zap.String(traceIDKey, testTraceID), zap.String(spanIDKey, "span")}
+	store := NewStore()
+	assert.Len(t, GetAll(Set(context.Background(), fields)), 1)
+	assert.Len(t, store.GetAll(store.Set(context.Background(), fields)), 2)
+}
+
+func TestStoreCarrier(t *testing.T) {
+	store := NewStore()
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	carrier := MapCarrier{}
+	store.Inject(ctx, carrier)
+	Inject(ctx, carrier)
+	assert.Equal(t, MapCarrier{CarrierPrefix + traceIDKey: testTraceID}, carrier)
+
+	extracted := store.Extract(context.Background(), carrier)
+	assert.Empty(t, GetAll(extracted))
+	assert.Equal(t, store.GetAll(ctx), store.GetAll(extracted))
+}
+
+func TestStoreJSON(t *testing.T) {
+	store := NewStore()
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	data, err := store.EncodeJSON(ctx)
+	assert.NoError(t, err)
+	decoded, err := store.DecodeJSON(context.Background(), data)
+	assert.NoError(t, err)
+	assert.Empty(t, GetAll(decoded))
+	assert.Equal(t, store.GetAll(ctx), store.GetAll(decoded))
+}
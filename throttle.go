@@ -0,0 +1,114 @@
+package zax
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// NewThrottler returns a core letting through at most limit entries carrying
+// each value of key per window, such as 10 lines per minute per client ID.
+// Entries without key are always kept.
+//
+// Unlike [NewRateLimiter], it accounts for what it drops: once a throttled
+// value logs again in a later window, or when the core is synced, a warning
+// "suppressed log entries" with the value and the number of entries dropped
+// is written to core. Like [NewTraceSampler], the value is looked up in the
+// fields attached with [Logger] or [zap.Logger.With] as well as in the fields
+// of each entry.
+func NewThrottler(core zapcore.Core, key string, limit int, window time.Duration) zapcore.Core {
+	return &keyedLimiter{
+		Core:   core,
+		key:    key,
+		policy: &throttleWindows{root: core, key: key, limit: limit, window: window, counts: make(map[string]*throttleWindow)},
+	}
+}
+
+// throttleWindows are the current windows of the values of a key.
+type throttleWindows struct {
+	root   zapcore.Core
+	key    string
+	limit  int
+	window time.Duration
+
+	mu     sync.Mutex
+	counts map[string]*throttleWindow
+}
+
+type throttleWindow struct {
+	start      time.Time
+	count      int
+	suppressed int
+}
+
+// allow reports whether an entry with value logged at now fits in its window,
+// counting it either way.
+func (w *throttleWindows) allow(value string, now time.Time) bool {
+	w.mu.Lock()
+	var reports map[string]int
+	tw, ok := w.counts[value]
+	switch {
+	case !ok:
+		if len(w.counts) >= maxBuckets {
+			reports = w.evict(now)
+		}
+		tw = &throttleWindow{start: now}
+		w.counts[value] = tw
+	case now.Sub(tw.start) >= w.window:
+		if tw.suppressed > 0 {
+			reports = map[string]int{value: tw.suppressed}
+		}
+		*tw = throttleWindow{start: now}
+	}
+	allowed := tw.count < w.limit
+	if allowed {
+		tw.count++
+	} else {
+		tw.suppressed++
+	}
+	w.mu.Unlock()
+	w.report(reports, now)
+	return allowed
+}
+
+// flush reports the entries suppressed so far in every window.
+func (w *throttleWindows) flush(now time.Time) {
+	w.mu.Lock()
+	reports := make(map[string]int)
+	for value, tw := range w.counts {
+		if tw.suppressed > 0 {
+			reports[value] = tw.suppressed
+			tw.suppressed = 0
+		}
+	}
+	w.mu.Unlock()
+	w.report(reports, now)
+}
+
+// evict drops the windows that are over by now, and arbitrary others while
+// there are too many, returning the entries they suppressed. w.mu must be
+// held.
+func (w *throttleWindows) evict(now time.Time) map[string]int {
+	reports := make(map[string]int)
+	for value, tw := range w.counts {
+		if now.Sub(tw.start) >= w.window || len(w.counts) >= maxBuckets {
+			if tw.suppressed > 0 {
+				reports[value] = tw.suppressed
+			}
+			delete(w.counts, value)
+		}
+	}
+	return reports
+}
+
+// report writes a warning for each value with suppressed entries.
+func (w *throttleWindows) report(reports map[string]int, now time.Time) {
+	for value, suppressed := range reports {
+		ent := zapcore.Entry{Level: zapcore.WarnLevel, Time: now, Message: "suppressed log entries"}
+		if ce := w.root.Check(ent, nil); ce != nil {
+			ce.Write(zap.String(w.key, value), zap.Int("suppressed", suppressed), zap.Duration("window", w.window))
+		}
+	}
+}
@@ -0,0 +1,95 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestThrottler(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewThrottler(core, "client_id", 2, time.Minute))
+
+	noisy := Logger(Set(context.Background(), []zap.Field{zap.String("client_id", "noisy")}), logger)
+	for i := 0; i < 5; i++ {
+		noisy.Error("attached")
+	}
+	for i := 0; i < 4; i++ {
+		logger.Error("inline", zap.String("client_id", "inline"))
+	}
+	logger.Error("without key")
+	assert.Equal(t, 2, logs.FilterMessage("attached").Len())
+	assert.Equal(t, 2, logs.FilterMessage("inline").Len())
+	assert.Equal(t, 1, logs.FilterMessage("without key").Len())
+	assert.Zero(t, logs.FilterMessage("suppressed log entries").Len())
+
+	require.NoError(t, logger.Sync())
+	reports := logs.FilterMessage("suppressed log entries").AllUntimed()
+	require.Len(t, reports, 2)
+	suppressed := map[any]any{}
+	for _, report := range reports {
+		assert.Equal(t, zapcore.WarnLevel, report.Level)
+		suppressed[report.ContextMap()["client_id"]] = report.ContextMap()["suppressed"]
+	}
+	assert.Equal(t, map[any]any{"noisy": int64(3), "inline": int64(2)}, suppressed)
+
+	require.NoError(t, logger.Sync())
+	assert.Equal(t, 2, logs.FilterMessage("suppressed log entries").Len(), "reported entries are reset")
+}
+
+func TestThrottlerKeepsTeeLevels(t *testing.T) {
+	core, infoLogs, errorLogs := newTeeCore()
+	logger := zap.New(NewThrottler(core, "client_id", 2, time.Minute))
+
+	logger.Info("info", zap.String("client_id", "noisy"))
+	logger.Error("error", zap.String("client_id", "noisy"))
+	assert.Equal(t, 2, infoLogs.Len())
+	assert.Equal(t, 1, errorLogs.Len(), "the error-only core only gets the error")
+}
+
+// fixedClock is a clock stopped at a time.
+type fixedClock time.Time
+
+func (c fixedClock) Now() time.Time                         { return time.Time(c) }
+func (c fixedClock) NewTicker(d time.Duration) *time.Ticker { return time.NewTicker(d) }
+
+func TestThrottlerSyncClock(t *testing.T) {
+	at := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	t.Cleanup(func() { Configure(WithClock(nil)) })
+	Configure(WithClock(fixedClock(at)))
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(NewThrottler(core, "client_id", 1, time.Minute))
+
+	logger.Info("kept", zap.String("client_id", "noisy"))
+	logger.Info("suppressed", zap.String("client_id", "noisy"))
+	require.NoError(t, logger.Sync())
+	reports := logs.FilterMessage("suppressed log entries").All()
+	require.Len(t, reports, 1)
+	assert.Equal(t, at, reports[0].Time)
+}
+
+func TestThrottleWindows(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	w := &throttleWindows{root: core, key: "client_id", limit: 1, window: time.Minute, counts: make(map[string]*throttleWindow)}
+	now := time.Now()
+
+	assert.True(t, w.allow("a", now))
+	assert.False(t, w.allow("a", now.Add(time.Second)))
+	assert.False(t, w.allow("a", now.Add(2*time.Second)))
+	assert.True(t, w.allow("b", now), "values are throttled independently")
+	assert.Zero(t, logs.Len())
+
+	assert.True(t, w.allow("a", now.Add(time.Minute)), "a new window starts")
+	require.Equal(t, 1, logs.Len())
+	report := logs.All()[0]
+	assert.Equal(t, "suppressed log entries", report.Message)
+	assert.Equal(t, map[string]any{"client_id": "a", "suppressed": int64(2), "window": time.Minute}, report.ContextMap())
+}
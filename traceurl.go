@@ -0,0 +1,74 @@
+package zax
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// TraceURLKey is the key of the field added by [AppendTraceURL].
+const TraceURLKey = "trace_url"
+
+// TraceIDPlaceholder is replaced by the trace ID in trace URL templates.
+const TraceIDPlaceholder = "{trace_id}"
+
+// JaegerTraceURL returns the trace URL template of the Jaeger UI served at
+// base, such as "https://jaeger.example.com".
+func JaegerTraceURL(base string) string {
+	return strings.TrimSuffix(base, "/") + "/trace/" + TraceIDPlaceholder
+}
+
+// DatadogTraceURL returns the trace URL template of the Datadog site, such as
+// "datadoghq.com" or "datadoghq.eu".
+func DatadogTraceURL(site string) string {
+	return "https://app." + site + "/apm/trace/" + TraceIDPlaceholder
+}
+
+// WithTraceURL sets the template of the URLs rendered by [TraceURL]: any URL
+// containing [TraceIDPlaceholder], such as those returned by
+// [JaegerTraceURL] and [DatadogTraceURL], or a Grafana link to a Tempo trace.
+func WithTraceURL(template string) Option {
+	return func(c *config) {
+		c.traceURL = template
+	}
+}
+
+// TraceURL renders the trace URL template set with [WithTraceURL] with the
+// trace ID stored in ctx, see [TraceIDKey]. It reports false if no template
+// is set or ctx holds no trace ID.
+func TraceURL(ctx context.Context) (string, bool) {
+	return defaultStore.TraceURL(ctx)
+}
+
+// TraceURL renders the trace URL of the store in ctx; see [TraceURL].
+func (s *Store) TraceURL(ctx context.Context) (string, bool) {
+	template := s.loadConfig().traceURL
+	if template == "" {
+		return "", false
+	}
+	field, ok := s.GetField(ctx, TraceIDKey.Name())
+	if !ok || field.Type != zapcore.StringType || field.String == "" {
+		return "", false
+	}
+	return strings.ReplaceAll(template, TraceIDPlaceholder, url.PathEscape(field.String)), true
+}
+
+// AppendTraceURL appends the trace URL of ctx, see [TraceURL], to ctx under
+// [TraceURLKey], so that log viewers render a link from every entry to its
+// trace. ctx is returned as is if it has no trace URL.
+func AppendTraceURL(ctx context.Context) context.Context {
+	return defaultStore.AppendTraceURL(ctx)
+}
+
+// AppendTraceURL appends the trace URL of the store in ctx; see
+// [AppendTraceURL].
+func (s *Store) AppendTraceURL(ctx context.Context) context.Context {
+	traceURL, ok := s.TraceURL(ctx)
+	if !ok {
+		return ctx
+	}
+	return s.Append(ctx, []zap.Field{zap.String(TraceURLKey, traceURL)})
+}
@@ -0,0 +1,47 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestTraceURL(t *testing.T) {
+	store := NewStore(WithTraceURL(JaegerTraceURL("https://jaeger.example.com/")))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(TraceIDKey.Name(), "4bf92f35")})
+
+	traceURL, ok := store.TraceURL(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, "https://jaeger.example.com/trace/4bf92f35", traceURL)
+
+	ctx = store.AppendTraceURL(ctx)
+	field, ok := store.GetField(ctx, TraceURLKey)
+	assert.True(t, ok)
+	assert.Equal(t, zap.String(TraceURLKey, "https://jaeger.example.com/trace/4bf92f35"), field)
+}
+
+func TestTraceURLTemplates(t *testing.T) {
+	assert.Equal(t, "https://app.datadoghq.eu/apm/trace/{trace_id}", DatadogTraceURL("datadoghq.eu"))
+
+	store := NewStore(WithTraceURL("https://tempo.example.com/trace?id={trace_id}&again={trace_id}"))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(TraceIDKey.Name(), "a/b")})
+	traceURL, _ := store.TraceURL(ctx)
+	assert.Equal(t, "https://tempo.example.com/trace?id=a%2Fb&again=a%2Fb", traceURL)
+}
+
+func TestTraceURLMissing(t *testing.T) {
+	plain := NewStore()
+	withID := plain.Set(context.Background(), []zap.Field{zap.String(TraceIDKey.Name(), "4bf92f35")})
+	_, ok := plain.TraceURL(withID)
+	assert.False(t, ok, "no template")
+
+	store := NewStore(WithTraceURL(JaegerTraceURL("https://jaeger.example.com")))
+	_, ok = store.TraceURL(context.Background())
+	assert.False(t, ok, "no trace ID")
+	ctx := store.Set(context.Background(), []zap.Field{zap.Int(TraceIDKey.Name(), 7)})
+	assert.Equal(t, ctx, store.AppendTraceURL(ctx), "trace ID of another type")
+}
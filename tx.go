@@ -0,0 +1,89 @@
+package zax
+
+import (
+	"context"
+	"errors"
+	"sync"
+
+	"go.uber.org/zap"
+)
+
+// ErrTxDone is returned when committing a [Tx] already committed or rolled
+// back.
+var ErrTxDone = errors.New("zax: transaction already committed or rolled back")
+
+// Tx stages fields to be appended to a context at once, or not at all. It lets
+// a handler collect speculative fields, such as user information parsed from
+// a request but not validated yet, and only publish them to the logging
+// context once validation succeeds:
+//
+//	tx := zax.Begin(ctx)
+//	tx.Append(zap.String("user_id", claims.Subject))
+//	if err := validate(claims); err != nil {
+//		tx.Rollback()
+//		return err
+//	}
+//	ctx, err = tx.Commit()
+//
+// A Tx is safe for concurrent use.
+type Tx struct {
+	store *Store
+	ctx   context.Context
+
+	mu     sync.Mutex
+	staged []zap.Field
+	done   bool
+}
+
+// Begin starts staging fields to be appended to ctx.
+func Begin(ctx context.Context) *Tx {
+	return defaultStore.Begin(ctx)
+}
+
+// Begin starts staging fields to be appended to the store in ctx; see
+// [Begin].
+func (s *Store) Begin(ctx context.Context) *Tx {
+	return &Tx{store: s, ctx: ctx}
+}
+
+// Append stages fields. Fields staged after the transaction is done are
+// ignored.
+func (t *Tx) Append(fields ...zap.Field) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if !t.done {
+		t.staged = concatFields(fields, t.staged)
+	}
+}
+
+// Fields returns a copy of the staged fields, newest first.
+func (t *Tx) Fields() []zap.Field {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return concatFields(t.staged, nil)
+}
+
+// Commit appends the staged fields to the context given to [Begin] in a
+// single write, like [TryAppend], and returns the resulting context. The
+// transaction is then done, even if the write is rejected: committing it
+// again returns the context given to Begin and [ErrTxDone].
+func (t *Tx) Commit() (context.Context, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.done {
+		return t.ctx, ErrTxDone
+	}
+	t.done = true
+	staged := t.staged
+	t.staged = nil
+	return t.store.TryAppend(t.ctx, staged)
+}
+
+// Rollback discards the staged fields and ends the transaction. It is a
+// no-op if the transaction is already done, so it can be deferred.
+func (t *Tx) Rollback() {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.done = true
+	t.staged = nil
+}
@@ -0,0 +1,62 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestTxCommit(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	tx := Begin(ctx)
+	tx.Append(zap.String("user_id", "u-1"), zap.String("role", "guest"))
+	tx.Append(zap.String("role", "admin"))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(ctx), "staged fields are not published")
+
+	committed, err := tx.Commit()
+	require.NoError(t, err)
+	expected := Append(Append(ctx, []zap.Field{zap.String("user_id", "u-1"), zap.String("role", "guest")}), []zap.Field{zap.String("role", "admin")})
+	assert.Equal(t, GetAll(expected), GetAll(committed))
+	field, _ := GetField(committed, "role")
+	assert.Equal(t, "admin", field.String)
+
+	again, err := tx.Commit()
+	assert.ErrorIs(t, err, ErrTxDone)
+	assert.Equal(t, ctx, again)
+	tx.Append(zap.String("late", "ignored"))
+	assert.Empty(t, tx.Fields())
+}
+
+func TestTxRollback(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	tx := Begin(ctx)
+	defer tx.Rollback()
+	tx.Append(zap.String("user_id", "u-1"))
+	assert.Equal(t, []zap.Field{zap.String("user_id", "u-1")}, tx.Fields())
+	tx.Rollback()
+
+	committed, err := tx.Commit()
+	assert.ErrorIs(t, err, ErrTxDone)
+	assert.Equal(t, ctx, committed)
+	assert.Empty(t, tx.Fields())
+}
+
+func TestTxCommitInvalid(t *testing.T) {
+	store := NewStore(WithKeyValidator(SnakeCase, ValidationDrop))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	tx := store.Begin(ctx)
+	tx.Append(zap.String("User ID", "u-1"))
+	committed, err := tx.Commit()
+
+	var invalid *InvalidKeyError
+	assert.ErrorAs(t, err, &invalid)
+	assert.Equal(t, ctx, committed)
+}
@@ -0,0 +1,153 @@
+package zax
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"go.uber.org/zap"
+)
+
+// KeyValidator checks the key of a field about to be written to a context.
+// It returns the key to store, which lets it normalize keys silently, or an
+// error to reject the field.
+type KeyValidator func(key string) (string, error)
+
+// ValidationMode decides what Set and Append do with a field whose key is
+// rejected by the configured [KeyValidator]. [TrySet] and [TryAppend] return
+// an error instead, whatever the mode.
+type ValidationMode int
+
+const (
+	// ValidationPanic panics with an [*InvalidKeyError].
+	ValidationPanic ValidationMode = iota
+	// ValidationDrop silently leaves the field out.
+	ValidationDrop
+)
+
+// InvalidKeyError is the error reported when a [KeyValidator] rejects a key.
+type InvalidKeyError struct {
+	Key string
+	Err error
+}
+
+func (e *InvalidKeyError) Error() string {
+	return fmt.Sprintf("zax: invalid key %q: %v", e.Key, e.Err)
+}
+
+func (e *InvalidKeyError) Unwrap() error {
+	return e.Err
+}
+
+// SetKeyValidator sets the validator applied to the keys of the fields
+// written by every subsequent Set and Append, and what happens to rejected
+// fields. A nil validator disables validation.
+func SetKeyValidator(validator KeyValidator, mode ValidationMode) {
+	Configure(WithKeyValidator(validator, mode))
+}
+
+// TrySet is like [Set], but returns ctx unchanged along with an
+// [*InvalidKeyError] if the configured [KeyValidator] rejects a key.
+func TrySet(ctx context.Context, fields []zap.Field) (context.Context, error) {
+	return defaultStore.TrySet(ctx, fields)
+}
+
+// TrySet is like [Store.Set], but reports rejected keys; see [TrySet].
+func (s *Store) TrySet(ctx context.Context, fields []zap.Field) (context.Context, error) {
+	return s.write(ctx, nil, fields, opSet, true)
+}
+
+// TryAppend is like [Append], but returns ctx unchanged along with an
+// [*InvalidKeyError] if the configured [KeyValidator] rejects a key.
+func TryAppend(ctx context.Context, fields []zap.Field) (context.Context, error) {
+	return defaultStore.TryAppend(ctx, fields)
+}
+
+// TryAppend is like [Store.Append], but reports rejected keys; see
+// [TryAppend].
+func (s *Store) TryAppend(ctx context.Context, fields []zap.Field) (context.Context, error) {
+	return s.write(ctx, nil, fields, opAppend, true)
+}
+
+// validateKeys returns a copy of fields with validated keys.
+func (c *config) validateKeys(fields []zap.Field, strict bool) ([]zap.Field, error) {
+	if c.validator == nil || len(fields) == 0 {
+		return fields, nil
+	}
+	valid := make([]zap.Field, 0, len(fields))
+	for _, field := range fields {
+		key, err := c.validator(field.Key)
+		if err == nil {
+			field.Key = key
+			valid = append(valid, field)
+			continue
+		}
+		err = &InvalidKeyError{Key: field.Key, Err: err}
+		if strict {
+			return nil, err
+		}
+		if c.validation == ValidationPanic {
+			panic(err)
+		}
+	}
+	return valid, nil
+}
+
+// ChainValidators returns a validator applying validators in order, each to
+// the key returned by the previous one.
+func ChainValidators(validators ...KeyValidator) KeyValidator {
+	return func(key string) (string, error) {
+		for _, validator := range validators {
+			var err error
+			if key, err = validator(key); err != nil {
+				return key, err
+			}
+		}
+		return key, nil
+	}
+}
+
+var errNotSnakeCase = errors.New("not snake_case")
+
+// SnakeCase rejects keys that aren't made of lower case letters, digits and
+// single underscores.
+func SnakeCase(key string) (string, error) {
+	if key == "" || strings.HasPrefix(key, "_") || strings.HasSuffix(key, "_") || strings.Contains(key, "__") {
+		return key, errNotSnakeCase
+	}
+	for i := 0; i < len(key); i++ {
+		if !isSnakeCaseByte(key[i]) {
+			return key, errNotSnakeCase
+		}
+	}
+	return key, nil
+}
+
+// isSnakeCaseByte reports whether b is a lower case letter, a digit or an
+// underscore.
+func isSnakeCaseByte(b byte) bool {
+	return isASCIILower(b) || isASCIIDigit(b) || b == '_'
+}
+
+// MaxKeyLength rejects keys longer than n bytes.
+func MaxKeyLength(n int) KeyValidator {
+	return func(key string) (string, error) {
+		if len(key) > n {
+			return key, fmt.Errorf("longer than %d bytes", n)
+		}
+		return key, nil
+	}
+}
+
+// ReservedPrefixes rejects keys starting with any of prefixes.
+func ReservedPrefixes(prefixes ...string) KeyValidator {
+	return func(key string) (string, error) {
+		for _, prefix := range prefixes {
+			if strings.HasPrefix(key, prefix) {
+				return key, fmt.Errorf("reserved prefix %q", prefix)
+			}
+		}
+		return key, nil
+	}
+}
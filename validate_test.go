@@ -0,0 +1,79 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/zap"
+)
+
+func TestKeyValidators(t *testing.T) {
+	tests := map[string]struct {
+		validator KeyValidator
+		key       string
+		valid     bool
+	}{
+		"snake case":             {validator: SnakeCase, key: "trace_id", valid: true},
+		"camel case":             {validator: SnakeCase, key: "traceId"},
+		"double underscore":      {validator: SnakeCase, key: "trace__id"},
+		"leading underscore":     {validator: SnakeCase, key: "_trace"},
+		"short enough":           {validator: MaxKeyLength(8), key: "trace_id", valid: true},
+		"too long":               {validator: MaxKeyLength(7), key: "trace_id"},
+		"reserved prefix":        {validator: ReservedPrefixes("_", "zax."), key: "zax.internal"},
+		"unreserved prefix":      {validator: ReservedPrefixes("_", "zax."), key: "trace_id", valid: true},
+		"chain rejects":          {validator: ChainValidators(SnakeCase, MaxKeyLength(4)), key: "trace_id"},
+		"chain accepts":          {validator: ChainValidators(SnakeCase, MaxKeyLength(8)), key: "trace_id", valid: true},
+		"chain stops at failure": {validator: ChainValidators(MaxKeyLength(1), SnakeCase), key: "ab"},
+	}
+
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			key, err := tc.validator(tc.key)
+			assert.Equal(t, tc.key, key)
+			assert.Equal(t, tc.valid, err == nil, err)
+		})
+	}
+}
+
+func TestKeyValidation(t *testing.T) {
+	t.Cleanup(func() { SetKeyValidator(nil, ValidationPanic) })
+
+	normalize := func(key string) (string, error) {
+		return strings.ToLower(key), nil
+	}
+	SetKeyValidator(ChainValidators(normalize, SnakeCase), ValidationPanic)
+
+	ctx := Set(context.Background(), []zap.Field{zap.String("TRACE_ID", testTraceID)})
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(ctx))
+
+	assert.PanicsWithError(t, `zax: invalid key "trace-id": not snake_case`, func() {
+		Append(ctx, []zap.Field{zap.String("trace-id", testTraceID)})
+	})
+
+	SetKeyValidator(SnakeCase, ValidationDrop)
+	ctx = Append(ctx, []zap.Field{zap.String("spanID", "span"), zap.Int("attempt", 1)})
+	assert.Equal(t, []zap.Field{zap.Int("attempt", 1), zap.String(traceIDKey, testTraceID)}, GetAll(ctx))
+}
+
+func TestTrySetAndTryAppend(t *testing.T) {
+	t.Cleanup(func() { SetKeyValidator(nil, ValidationPanic) })
+	SetKeyValidator(SnakeCase, ValidationDrop)
+
+	ctx, err := TrySet(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	require.NoError(t, err)
+
+	unchanged, err := TryAppend(ctx, []zap.Field{zap.String("spanID", "span")})
+	var invalid *InvalidKeyError
+	require.ErrorAs(t, err, &invalid)
+	assert.Equal(t, "spanID", invalid.Key)
+	assert.ErrorIs(t, err, errNotSnakeCase)
+	assert.Equal(t, ctx, unchanged)
+
+	_, err = TrySet(ctx, []zap.Field{zap.String("", "")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,92 @@
+package zax
+
+import (
+	"fmt"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Value returns the value of field as a Go value, so that readers of
+// [GetField] results don't need to know how zap stores each type: string,
+// bool, int64, uint64, float64, float32, time.Duration and time.Time for the
+// corresponding types, and the original value, such as an error, a
+// fmt.Stringer or a []byte, for the others. Skipped fields have no value.
+func Value(field zap.Field) any {
+	switch field.Type {
+	case zapcore.UnknownType, zapcore.SkipType, zapcore.NamespaceType:
+		return nil
+	case zapcore.StringerType, zapcore.ByteStringType, zapcore.ErrorType:
+		return field.Interface
+	}
+	if codec, ok := jsonCodecs[field.Type]; ok {
+		return codec.encode(field)
+	}
+	return field.Interface
+}
+
+// EncodedValue returns the value zap itself encodes for field, as plain Go
+// maps, slices and scalars, so that codecs implemented in other packages can
+// serialize fields of any type, such as arrays and objects. Inline objects
+// give a map of the keys they add.
+func EncodedValue(field zap.Field) any {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	if value, ok := enc.Fields[field.Key]; ok {
+		return value
+	}
+	// Inlined objects add their own keys to the encoder.
+	return enc.Fields
+}
+
+// MustString returns the value of a string field, and panics for other types.
+func MustString(field zap.Field) string {
+	return mustValue[string](field, "string")
+}
+
+// MustBool returns the value of a bool field, and panics for other types.
+func MustBool(field zap.Field) bool {
+	return mustValue[bool](field, "bool")
+}
+
+// MustInt64 returns the value of a signed integer field of any size, and
+// panics for other types.
+func MustInt64(field zap.Field) int64 {
+	return mustValue[int64](field, "signed integer")
+}
+
+// MustUint64 returns the value of an unsigned integer field of any size, and
+// panics for other types.
+func MustUint64(field zap.Field) uint64 {
+	return mustValue[uint64](field, "unsigned integer")
+}
+
+// MustFloat64 returns the value of a float field of any size, and panics for
+// other types.
+func MustFloat64(field zap.Field) float64 {
+	if value, ok := Value(field).(float32); ok {
+		return float64(value)
+	}
+	return mustValue[float64](field, "float")
+}
+
+// MustDuration returns the value of a duration field, and panics for other
+// types.
+func MustDuration(field zap.Field) time.Duration {
+	return mustValue[time.Duration](field, "duration")
+}
+
+// MustTime returns the value of a time field, and panics for other types.
+func MustTime(field zap.Field) time.Time {
+	return mustValue[time.Time](field, "time")
+}
+
+// mustValue returns the value of field, and panics unless it is a T.
+func mustValue[T any](field zap.Field, kind string) T {
+	value, ok := Value(field).(T)
+	if !ok {
+		panic(fmt.Sprintf("zax: field %q does not hold a %s", field.Key, kind))
+	}
+	return value
+}
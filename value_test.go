@@ -0,0 +1,71 @@
+package zax
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+type stringer struct{}
+
+func (stringer) String() string { return "stringer" }
+
+func TestValue(t *testing.T) {
+	now := time.Now()
+	err := errors.New("failed")
+	tests := map[string]struct {
+		field    zap.Field
+		expected any
+	}{
+		"string":     {zap.String("k", "v"), "v"},
+		"bool":       {zap.Bool("k", true), true},
+		"int8":       {zap.Int8("k", -8), int64(-8)},
+		"uint16":     {zap.Uint16("k", 16), uint64(16)},
+		"float64":    {zap.Float64("k", 1.5), 1.5},
+		"float32":    {zap.Float32("k", 2.5), float32(2.5)},
+		"duration":   {zap.Duration("k", time.Second), time.Second},
+		"time":       {zap.Time("k", now), now},
+		"error":      {zap.NamedError("k", err), err},
+		"stringer":   {zap.Stringer("k", stringer{}), stringer{}},
+		"bytestring": {zap.ByteString("k", []byte("b")), []byte("b")},
+		"strings":    {zap.Strings("k", []string{"a"}), zap.Strings("k", []string{"a"}).Interface},
+		"reflect":    {zap.Reflect("k", []int{1}), []int{1}},
+		"skip":       {zap.Skip(), nil},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			value := Value(tt.field)
+			if expected, ok := tt.expected.(time.Time); ok {
+				assert.True(t, expected.Equal(value.(time.Time)))
+				return
+			}
+			assert.Equal(t, tt.expected, value)
+		})
+	}
+}
+
+func TestMust(t *testing.T) {
+	assert.Equal(t, "v", MustString(zap.String("k", "v")))
+	assert.True(t, MustBool(zap.Bool("k", true)))
+	assert.Equal(t, int64(-3), MustInt64(zap.Int32("k", -3)))
+	assert.Equal(t, uint64(3), MustUint64(zap.Uint8("k", 3)))
+	assert.Equal(t, 2.5, MustFloat64(zap.Float32("k", 2.5)))
+	assert.Equal(t, time.Minute, MustDuration(zap.Duration("k", time.Minute)))
+	assert.Equal(t, int64(0), MustTime(zap.Time("k", time.Unix(0, 0))).UnixNano())
+
+	assert.PanicsWithValue(t, `zax: field "k" does not hold a string`, func() { MustString(zap.Int("k", 1)) })
+	assert.Panics(t, func() { MustInt64(zap.Uint64("k", 1)) })
+	assert.Panics(t, func() { MustFloat64(zap.String("k", "1.5")) })
+}
+
+func TestEncodedValue(t *testing.T) {
+	assert.Equal(t, []any{"a", "b"}, EncodedValue(zap.Strings("k", []string{"a", "b"})))
+	assert.Equal(t, map[string]any{"user": "alice"}, EncodedValue(zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+		enc.AddString("user", "alice")
+		return nil
+	}))))
+}
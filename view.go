@@ -0,0 +1,84 @@
+package zax
+
+import (
+	"context"
+
+	"go.uber.org/zap"
+)
+
+// FieldView is a read-only accessor to the fields stored in a context, newest
+// first. Unlike [GetAll], it doesn't copy them. The zero FieldView is empty.
+type FieldView struct {
+	fields []zap.Field
+}
+
+// View returns a read-only accessor to the fields stored in ctx.
+func View(ctx context.Context) FieldView {
+	return defaultStore.View(ctx)
+}
+
+// View returns a read-only accessor to the fields of s in ctx; see [View].
+func (s *Store) View(ctx context.Context) FieldView {
+	cfg := s.loadConfig()
+	return FieldView{fields: cfg.mapFields(cfg.withDefaults(s.rawFields(ctx)))}
+}
+
+// StoredView is like [View], but without the defaults (see [SetDefaults]) and
+// with unmapped keys (see [WithKeyMapper]): it gives access to the fields
+// that are serialized by [MarshalJSON], [MarshalBinary] and [Enqueue], for
+// codecs implemented in other packages.
+func StoredView(ctx context.Context) FieldView {
+	return defaultStore.StoredView(ctx)
+}
+
+// StoredView returns a read-only accessor to the fields of s in ctx, as
+// stored; see [StoredView].
+func (s *Store) StoredView(ctx context.Context) FieldView {
+	return FieldView{fields: s.rawFields(ctx)}
+}
+
+// Len returns the number of fields.
+func (v FieldView) Len() int {
+	return len(v.fields)
+}
+
+// At returns the i-th field, newest first. It panics if i is out of range.
+func (v FieldView) At(i int) zap.Field {
+	return v.fields[i]
+}
+
+// Get returns the newest field with key, if any.
+func (v FieldView) Get(key string) (zap.Field, bool) {
+	for _, field := range v.fields {
+		if field.Key == key {
+			return field, true
+		}
+	}
+	return zap.Field{}, false
+}
+
+// Range calls fn for each field, newest first, until fn returns false.
+func (v FieldView) Range(fn func(zap.Field) bool) {
+	for _, field := range v.fields {
+		if !fn(field) {
+			return
+		}
+	}
+}
+
+// Fields returns a copy of the fields, as [GetAll] does.
+func (v FieldView) Fields() []zap.Field {
+	if v.fields == nil {
+		return nil
+	}
+	return append(make([]zap.Field, 0, len(v.fields)), v.fields...)
+}
+
+// Unsafe returns the fields without copying them: the returned slice may be
+// the one stored in the context, and shared with every context derived from
+// it. It is an escape hatch for hot paths handing fields to functions known
+// not to modify them, such as zap's logging methods; callers must never
+// modify its elements or append to it.
+func (v FieldView) Unsafe() []zap.Field {
+	return v.fields
+}
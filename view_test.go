@@ -0,0 +1,64 @@
+//go:build !zax_disabled
+
+package zax
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestView(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID), zap.Int("attempt", 2)})
+	view := View(ctx)
+
+	assert.Equal(t, 2, view.Len())
+	assert.Equal(t, zap.String(traceIDKey, testTraceID), view.At(0))
+	field, ok := view.Get("attempt")
+	assert.True(t, ok)
+	assert.Equal(t, zap.Int("attempt", 2), field)
+	_, ok = view.Get("missing")
+	assert.False(t, ok)
+
+	var keys []string
+	view.Range(func(field zap.Field) bool {
+		keys = append(keys, field.Key)
+		return false
+	})
+	assert.Equal(t, []string{traceIDKey}, keys)
+
+	assert.Equal(t, GetAll(ctx), view.Fields())
+	assert.Equal(t, GetAll(ctx), view.Unsafe())
+}
+
+func TestViewEmpty(t *testing.T) {
+	var zero FieldView
+	assert.Equal(t, 0, zero.Len())
+	assert.Nil(t, zero.Fields())
+
+	view := View(context.Background())
+	assert.Equal(t, 0, view.Len())
+	assert.Nil(t, view.Fields())
+	assert.Nil(t, GetAll(context.Background()))
+}
+
+func TestGetAllCopies(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	fields := GetAll(ctx)
+	fields[0] = zap.String(traceIDKey, "tampered")
+	_ = append(fields[:0], zap.String("other", "value"))
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(ctx))
+	assert.Equal(t, zap.String(traceIDKey, testTraceID), View(ctx).At(0))
+}
+
+func TestStoredView(t *testing.T) {
+	store := NewStore(WithDefaults(zap.String("service", "api")), WithKeyMapper(PrefixKeys("app.")))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, store.StoredView(ctx).Fields())
+	assert.Equal(t, 2, store.View(ctx).Len())
+}
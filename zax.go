@@ -4,6 +4,8 @@ package zax
 
 import (
 	"context"
+	"sync"
+	"sync/atomic"
 
 	"go.uber.org/zap"
 )
@@ -19,51 +21,266 @@ const (
 	AbsentFieldsKey string = "_absentFields"
 )
 
+// container is the value zax stores in a context. It is never modified once
+// stored: Set and Append always store a new container, which also discards
+// anything cached on the previous one.
+type container struct {
+	// fields are all the stored fields: the unscoped ones, followed by the
+	// ones of each scope; see [Scope].
+	fields []zap.Field
+	// unscoped are the fields written by Set and Append.
+	unscoped []zap.Field
+	// scopes are the fields of every non-empty scope, in scope creation order.
+	scopes []scopeFields
+
+	// loggers caches the loggers derived by attaching fields, per base logger
+	// and config, holding about cachedLoggers of them; see [Logger].
+	loggers       sync.Map
+	cachedLoggers atomic.Int32
+}
+
+// maxCachedLoggers bounds the number of loggers cached per context, so that
+// contexts logged to with many base loggers don't grow without bound.
+const maxCachedLoggers = 8
+
+// loggerCacheKey identifies a logger derived from a context: the config is
+// part of it so that Configure applies to contexts logged to before.
+type loggerCacheKey struct {
+	base *zap.Logger
+	cfg  *config
+}
+
+// logger returns the logger derived from base with cfg, calling derive and
+// caching its result on a miss.
+func (c *container) logger(base *zap.Logger, cfg *config, derive func() *zap.Logger) *zap.Logger {
+	key := loggerCacheKey{base: base, cfg: cfg}
+	if cached, ok := c.loggers.Load(key); ok {
+		return cached.(*zap.Logger)
+	}
+	if c.cachedLoggers.Add(1) > maxCachedLoggers {
+		c.loggers.Range(func(key, _ any) bool {
+			c.loggers.Delete(key)
+			return true
+		})
+		c.cachedLoggers.Store(1)
+	}
+	cached, _ := c.loggers.LoadOrStore(key, derive())
+	return cached.(*zap.Logger)
+}
+
 // Set Add passed fields in context
 func Set(ctx context.Context, fields []zap.Field) context.Context {
-	return context.WithValue(ctx, loggerKey, fields)
+	return defaultStore.Set(ctx, fields)
+}
+
+// Set replaces the fields of the store in ctx with fields; see [Set].
+func (s *Store) Set(ctx context.Context, fields []zap.Field) context.Context {
+	ctx, _ = s.write(ctx, nil, fields, opSet, false)
+	return ctx
 }
 
 // Append  appending passed fields to the existing fields in context.
 // it's recommended to use Append when you want to append some fields and do not lose the already added fields to context.
+// The context owns a copy of fields: contexts appended to the same parent never
+// observe each other's fields, even if fields is later reused or modified.
 func Append(ctx context.Context, fields []zap.Field) context.Context {
-	if loggerFields, ok := ctx.Value(loggerKey).([]zap.Field); ok {
-		fields = append(fields, loggerFields...)
+	return defaultStore.Append(ctx, fields)
+}
+
+// Append adds fields to the fields of the store in ctx; see [Append].
+func (s *Store) Append(ctx context.Context, fields []zap.Field) context.Context {
+	ctx, _ = s.write(ctx, nil, fields, opAppend, false)
+	return ctx
+}
+
+// WithFields runs fn with ctx extended by fields, appended like by [Append],
+// and returns the error of fn. The fields are only visible within fn, which
+// avoids shadowing ctx by hand and accidentally logging them afterwards:
+//
+//	err := zax.WithFields(ctx, []zap.Field{zap.Int("attempt", n)}, func(ctx context.Context) error {
+//		return send(ctx, msg)
+//	})
+func WithFields(ctx context.Context, fields []zap.Field, fn func(context.Context) error) error {
+	return defaultStore.WithFields(ctx, fields, fn)
+}
+
+// WithFields runs fn with fields appended to the store in ctx; see
+// [WithFields].
+func (s *Store) WithFields(ctx context.Context, fields []zap.Field, fn func(context.Context) error) error {
+	return fn(s.Append(ctx, fields))
+}
+
+// writeOp tells whether a write replaces or extends the stored fields.
+type writeOp int
+
+const (
+	opSet writeOp = iota
+	opAppend
+)
+
+// write stores fields in ctx, in scope or unscoped if scope is nil,
+// according to op. If strict, a key rejected by the configured
+// [KeyValidator] aborts the write with an error, instead of being handled
+// according to the configured [ValidationMode].
+func (s *Store) write(ctx context.Context, scope *Scope, fields []zap.Field, op writeOp, strict bool) (context.Context, error) {
+	if disabled {
+		return ctx, nil
+	}
+	cfg := s.loadConfig()
+	fields, err := cfg.prepare(fields, strict)
+	if err != nil {
+		return ctx, err
+	}
+	written := fields
+	c := s.fromContext(ctx)
+	if op == opAppend {
+		runHooks(ctx, cfg.appendHooks, fields)
+		fields = cfg.detectCollisions(ctx, fields, c.part(scope))
+	} else {
+		runHooks(ctx, cfg.setHooks, fields)
+		// Copy fields so that the caller reusing its slice doesn't alter the
+		// context.
+		fields = concatFields(fields, nil)
+	}
+	if cfg.autoPrune {
+		fields = uniqueFields(fields)
+	}
+	fields = cfg.limits.enforce(fields)
+	next := c.with(scope, fields)
+	cfg.stats.record(op, written, len(next.fields))
+	if cfg.provenance {
+		ctx = s.recordProvenance(ctx, WriteEvent{Fields: written}.Keys())
 	}
-	return context.WithValue(ctx, loggerKey, fields)
+	ctx = context.WithValue(ctx, s.key, next)
+	export(ctx, cfg.exporters, Change{Appended: op == opAppend, Written: written, Fields: next.fields})
+	return ctx, nil
 }
 
-// GetAll zap stored fields from context
+// GetAll zap stored fields from context. The returned slice is a copy, which
+// callers are free to modify; see [View] to access the fields without copying
+// them.
 func GetAll(ctx context.Context) []zap.Field {
-	if loggerFields, ok := ctx.Value(loggerKey).([]zap.Field); ok {
-		return loggerFields
+	return defaultStore.GetAll(ctx)
+}
+
+// GetAll returns a copy of the fields of the store in ctx; see [GetAll].
+func (s *Store) GetAll(ctx context.Context) []zap.Field {
+	return s.View(ctx).Fields()
+}
+
+// rawFields returns the fields of s in ctx with their stored keys.
+func (s *Store) rawFields(ctx context.Context) []zap.Field {
+	if c := s.fromContext(ctx); c != nil {
+		return c.fields
 	}
 	return nil
 }
 
+// Logger returns logger with the fields stored in ctx attached.
+//
+// Attaching fields makes zap encode them, so the derived logger is cached on
+// the context: logging repeatedly against the same context and logger reuses
+// the already-encoded fields instead of re-encoding them for every entry. The
+// cache belongs to the stored fields, so Set and Append naturally invalidate it.
+//
+// The level override of ctx set by [WithLevel], if any, applies to the
+// returned logger.
+func Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	return defaultStore.Logger(ctx, logger)
+}
+
+// Logger returns logger with the fields of the store in ctx attached; see
+// [Logger].
+func (s *Store) Logger(ctx context.Context, logger *zap.Logger) *zap.Logger {
+	if disabled {
+		return logger
+	}
+	cfg := s.loadConfig()
+	if cfg.sampling != nil && !cfg.sampling.keep(cfg, s.fromContext(ctx)) {
+		return zap.NewNop()
+	}
+	return s.decorate(ctx, cfg, s.attach(ctx, cfg, logger))
+}
+
+// decorate returns logger with the level override and the enrichers of ctx,
+// and its deadline fields, if enabled.
+func (s *Store) decorate(ctx context.Context, cfg *config, logger *zap.Logger) *zap.Logger {
+	logger = withLevel(ctx, logger)
+	logger = withEnrichers(ctx, logger)
+	if cfg.deadlineFields {
+		logger = withDeadline(ctx, logger)
+	}
+	return logger
+}
+
+// attach returns logger with the fields of s in ctx attached, cached on the
+// stored fields along with cfg.
+func (s *Store) attach(ctx context.Context, cfg *config, logger *zap.Logger) *zap.Logger {
+	c := s.fromContext(ctx)
+	if c == nil || len(c.fields) == 0 {
+		if len(cfg.defaults) == 0 {
+			return logger
+		}
+		return logger.With(cfg.attached(cfg.defaults)...)
+	}
+	return c.logger(logger, cfg, func() *zap.Logger {
+		return logger.With(cfg.attached(cfg.withDefaults(c.fields))...)
+	})
+}
+
 // GetFields specified by keys.
 func GetFields(ctx context.Context, keys ...string) []zap.Field {
-	absentKeys := make([]string, 0, len(keys))
-	fields := make([]zap.Field, 0, len(keys))
+	return defaultStore.GetFields(ctx, keys...)
+}
+
+// GetFields returns the fields of the store in ctx with keys; see
+// [GetFields].
+func (s *Store) GetFields(ctx context.Context, keys ...string) []zap.Field {
+	return s.AppendFields(make([]zap.Field, 0, len(keys)+1), ctx, keys...)
+}
+
+// AppendFields is like [GetFields], but appends the fields to dst and returns
+// the extended slice. Services calling it on every request can reuse dst
+// between calls to avoid allocating a new slice each time.
+func AppendFields(dst []zap.Field, ctx context.Context, keys ...string) []zap.Field {
+	return defaultStore.AppendFields(dst, ctx, keys...)
+}
+
+// AppendFields appends the fields of the store in ctx with keys to dst; see
+// [AppendFields].
+func (s *Store) AppendFields(dst []zap.Field, ctx context.Context, keys ...string) []zap.Field {
+	var absentKeys []string
 	for _, key := range keys {
-		if field, ok := GetField(ctx, key); ok {
-			fields = append(fields, field)
+		if field, ok := s.GetField(ctx, key); ok {
+			dst = append(dst, field)
 		} else {
 			absentKeys = append(absentKeys, key)
 		}
 	}
 
-	return append(fields, zap.Strings(AbsentFieldsKey, absentKeys))
+	if s.loadConfig().omitAbsent {
+		return dst
+	}
+	return append(dst, zap.Strings(AbsentFieldsKey, absentKeys))
 }
 
 // GetField Get a specific zap stored field from context by key
 func GetField(ctx context.Context, key string) (field zap.Field, ok bool) {
-	if loggerFields, ok := ctx.Value(loggerKey).([]zap.Field); ok {
-		for _, field := range loggerFields {
+	return defaultStore.GetField(ctx, key)
+}
+
+// GetField returns the field of the store in ctx with key; see [GetField].
+func (s *Store) GetField(ctx context.Context, key string) (field zap.Field, ok bool) {
+	cfg := s.loadConfig()
+	for _, fields := range [2][]zap.Field{s.rawFields(ctx), cfg.defaults} {
+		for _, field := range fields {
 			if field.Key == key {
-				return field, true
+				return cfg.mapField(field), true
 			}
 		}
 	}
+	if counter := cfg.absentCounter; counter != nil {
+		counter.Inc(key)
+	}
 	return zap.Field{}, false
 }
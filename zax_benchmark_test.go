@@ -47,3 +47,16 @@ func BenchmarkLoggingWithZax(b *testing.B) {
 		LogWithZax(logger)
 	}
 }
+
+func BenchmarkLoggingWithZaxLogger(b *testing.B) {
+	// Create a no-op logger that discards log output
+	logger := zap.NewExample()
+	logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+		return zapcore.NewNopCore()
+	}))
+	ctx := Set(context.Background(), someFields)
+
+	for i := 1; i <= b.N; i++ {
+		Logger(ctx, logger).Info("logging something")
+	}
+}
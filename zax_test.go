@@ -1,3 +1,5 @@
+//go:build !zax_disabled
+
 package zax
 
 import (
@@ -6,68 +8,13 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
-	"go.uber.org/zap/zaptest/observer"
-)
-
-type Logger struct {
-	logger   *zap.Logger
-	recorded *observer.ObservedLogs
-	t        *testing.T
-}
-
-func NewLogger(t *testing.T) *Logger {
-	core, recorded := observer.New(zapcore.DebugLevel)
-	logger := &Logger{
-		logger:   zap.New(core),
-		recorded: recorded,
-		t:        t,
-	}
-	return logger
-}
-
-func (l *Logger) GetZapLogger() *zap.Logger {
-	return l.logger
-}
-
-func (l *Logger) GetRecordedLogs() []observer.LoggedEntry {
-	return l.recorded.All()
-}
-
-func (l *Logger) AssertLogEntryExist(t assert.TestingT, key, value string) bool {
-	for _, log := range l.recorded.All() {
-		for _, r := range log.Context {
-			if r.Key == key && r.String == value {
-				return true
-			}
-		}
-	}
-	if key == "" && value == "" {
-		return true
-	}
-	return assert.Fail(t, fmt.Sprintf("log entry does not exist with, %s = %s", key, value))
-}
-
-func (l *Logger) AssertLogEntryKeyExist(t assert.TestingT, key string) bool {
-	for _, log := range l.recorded.All() {
-		for _, r := range log.Context {
-			if r.Key == key {
-				return true
-			}
-		}
-	}
-	return assert.Fail(t, fmt.Sprintf("log entry does not exist with key = %s ", key))
-}
-
-const (
-	traceIDKey  = "trace_id"
-	spanIDKey   = "span_id"
-	testTraceID = "test-trace-id-3333"
 )
 
 func TestSet(t *testing.T) {
-	testLog := NewLogger(t)
+	testLog := newTestLogger(t)
 
 	testTraceID2 := "test-trace-id-new"
 	traceIDCtx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
@@ -96,7 +43,7 @@ func TestSet(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx := tc.context
-			logger := testLog.logger.With(GetAll(ctx)...)
+			logger := testLog.GetZapLogger().With(GetAll(ctx)...)
 			logger.Info("just a test record")
 			assert.NotNil(t, logger)
 			testLog.AssertLogEntryExist(t, tc.expectedLoggerKey, tc.expectedLoggerValue)
@@ -105,7 +52,7 @@ func TestSet(t *testing.T) {
 }
 
 func TestAppend(t *testing.T) {
-	testLog := NewLogger(t)
+	testLog := newTestLogger(t)
 	ctx := context.Background()
 	ctx = Set(ctx, []zap.Field{zap.String(traceIDKey, testTraceID)})
 	tests := map[string]struct {
@@ -125,7 +72,7 @@ func TestAppend(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx := tc.context
-			logger := testLog.logger.With(GetAll(ctx)...)
+			logger := testLog.GetZapLogger().With(GetAll(ctx)...)
 			logger.Info("just a test record")
 			assert.NotNil(t, logger)
 			assert.Equal(t, tc.expectedFieldNumber, len(GetAll(ctx)))
@@ -134,7 +81,7 @@ func TestAppend(t *testing.T) {
 }
 
 func TestGet(t *testing.T) {
-	testLog := NewLogger(t)
+	testLog := newTestLogger(t)
 	traceIDKey := traceIDKey
 	ctx := context.Background()
 	tests := map[string]struct {
@@ -154,7 +101,7 @@ func TestGet(t *testing.T) {
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
 			ctx := tc.context
-			testLog.logger.With(GetAll(ctx)...).Info("just a test record")
+			testLog.GetZapLogger().With(GetAll(ctx)...).Info("just a test record")
 			if tc.expectedLoggerKey != nil {
 				testLog.AssertLogEntryKeyExist(t, *tc.expectedLoggerKey)
 			}
@@ -223,3 +170,114 @@ func TestGetFields(t *testing.T) {
 	// BODGE: brittle to default print representation changes.
 	assert.Equal(t, fmt.Sprintf("[%s]", absentKey), fmt.Sprint(absentKeysField.Interface))
 }
+
+func TestLogger(t *testing.T) {
+	testLog := newTestLogger(t)
+	base := testLog.GetZapLogger()
+
+	assert.Same(t, base, Logger(context.Background(), base))
+
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	logger := Logger(ctx, base)
+	assert.NotSame(t, base, logger)
+	assert.Same(t, logger, Logger(ctx, base), "derived logger should be cached on the context")
+
+	logger.Info("just a test record")
+	testLog.AssertLogEntryExist(t, traceIDKey, testTraceID)
+
+	appended := Append(ctx, []zap.Field{zap.String(spanIDKey, "span")})
+	assert.NotSame(t, logger, Logger(appended, base), "Append should invalidate the cached logger")
+	Logger(appended, base).Info("another test record")
+	testLog.AssertLogEntryExist(t, spanIDKey, "span")
+}
+
+func TestLoggerAfterConfigure(t *testing.T) {
+	store := NewStore()
+	testLog := newTestLogger(t)
+	ctx := store.Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	store.Logger(ctx, testLog.GetZapLogger()).Info("before")
+
+	store.Configure(WithDefaults(zap.String("service", "api")), WithKeyMapper(PrefixKeys("app.")))
+	store.Logger(ctx, testLog.GetZapLogger()).Info("after")
+
+	entries := testLog.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, map[string]any{"app." + traceIDKey: testTraceID, "app.service": "api"}, entries[1].ContextMap())
+}
+
+func TestLoggerCacheIsBounded(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+	for i := 0; i < 10*maxCachedLoggers; i++ {
+		Logger(ctx, zap.NewNop())
+	}
+
+	cached := 0
+	defaultStore.fromContext(ctx).loggers.Range(func(_, _ any) bool {
+		cached++
+		return true
+	})
+	assert.LessOrEqual(t, cached, maxCachedLoggers)
+}
+
+func TestAppendFields(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	dst := make([]zap.Field, 0, 4)
+	fields := AppendFields(dst, ctx, traceIDKey)
+	assert.Len(t, fields, 2)
+	assert.Equal(t, traceIDKey, fields[0].Key)
+	assert.Equal(t, AbsentFieldsKey, fields[1].Key)
+	assert.Equal(t, "[]", fmt.Sprint(fields[1].Interface))
+
+	// dst has enough capacity, so no new backing array is allocated.
+	assert.Same(t, &dst[:1][0], &fields[0])
+
+	allocs := testing.AllocsPerRun(100, func() {
+		fields = AppendFields(fields[:0], ctx, traceIDKey)
+	})
+	assert.Zero(t, allocs)
+}
+
+func TestAppendIsolatesSiblings(t *testing.T) {
+	parent := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	// Reusing a slice with spare capacity for siblings must not let the second
+	// Append write into the fields of the first.
+	buf := make([]zap.Field, 1, 8)
+	buf[0] = zap.String("branch", "left")
+	left := Append(parent, buf)
+	buf[0] = zap.String("branch", "right")
+	right := Append(parent, buf)
+
+	// Diamond: both branches derive again from their own state.
+	leftChild := Append(left, []zap.Field{zap.Int("depth", 2)})
+	rightChild := Append(right, []zap.Field{zap.Int("depth", 3)})
+
+	assert.Equal(t, []zap.Field{zap.String("branch", "left"), zap.String(traceIDKey, testTraceID)}, GetAll(left))
+	assert.Equal(t, []zap.Field{zap.String("branch", "right"), zap.String(traceIDKey, testTraceID)}, GetAll(right))
+	assert.Equal(t, []zap.Field{zap.Int("depth", 2), zap.String("branch", "left"), zap.String(traceIDKey, testTraceID)}, GetAll(leftChild))
+	assert.Equal(t, []zap.Field{zap.Int("depth", 3), zap.String("branch", "right"), zap.String(traceIDKey, testTraceID)}, GetAll(rightChild))
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(parent))
+}
+
+func TestSetCopiesFields(t *testing.T) {
+	fields := []zap.Field{zap.String(traceIDKey, testTraceID)}
+	ctx := Set(context.Background(), fields)
+	fields[0] = zap.String(traceIDKey, "reused")
+
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(ctx))
+}
+
+func TestWithFields(t *testing.T) {
+	ctx := Set(context.Background(), []zap.Field{zap.String(traceIDKey, testTraceID)})
+
+	var inner []zap.Field
+	err := WithFields(ctx, []zap.Field{zap.Int("attempt", 2)}, func(ctx context.Context) error {
+		inner = GetAll(ctx)
+		return fmt.Errorf("attempt failed")
+	})
+
+	assert.EqualError(t, err, "attempt failed")
+	assert.Equal(t, []zap.Field{zap.Int("attempt", 2), zap.String(traceIDKey, testTraceID)}, inner)
+	assert.Equal(t, []zap.Field{zap.String(traceIDKey, testTraceID)}, GetAll(ctx), "fields don't escape the scope")
+}
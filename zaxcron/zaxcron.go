@@ -0,0 +1,59 @@
+// Package zaxcron runs robfig/cron jobs with a fresh context per run, holding
+// zax fields identifying the job and the run.
+package zaxcron
+
+import (
+	"context"
+	"runtime/debug"
+
+	"github.com/robfig/cron/v3"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields identifying a run.
+const (
+	JobKey      = "cron_job"
+	ScheduleKey = "cron_schedule"
+	RunIDKey    = "cron_run_id"
+)
+
+// Job returns a job running fn with a fresh context holding the name and
+// schedule of the job and a run ID, see [zax.UUIDv7]. The start and finish of
+// every run, along with its duration, are logged through logger with those
+// fields; a panic is recovered and logged instead of crashing the scheduler.
+//
+//	c.AddJob(spec, zaxcron.Job(logger, "cleanup", spec, cleanup))
+func Job(logger *zap.Logger, name, schedule string, fn func(context.Context)) cron.Job {
+	return cron.FuncJob(func() {
+		ctx := zax.Set(context.Background(), []zap.Field{
+			zap.String(JobKey, name),
+			zap.String(ScheduleKey, schedule),
+			zap.String(RunIDKey, zax.UUIDv7()),
+		})
+		logger := zax.Logger(ctx, logger)
+		clock := zax.Clock()
+		start := clock.Now()
+		defer func() {
+			if r := recover(); r != nil {
+				logger.Error("cron job panicked",
+					zap.Any("panic", r),
+					zap.ByteString("stack", debug.Stack()),
+					zap.Duration("duration", clock.Now().Sub(start)),
+				)
+			}
+		}()
+		logger.Info("cron job started")
+		fn(ctx)
+		logger.Info("cron job finished", zap.Duration("duration", clock.Now().Sub(start)))
+	})
+}
+
+// Wrapper returns a wrapper applying [Job] to jobs unaware of contexts:
+//
+//	c.AddJob(spec, cron.NewChain(zaxcron.Wrapper(logger, "cleanup", spec)).Then(job))
+func Wrapper(logger *zap.Logger, name, schedule string) cron.JobWrapper {
+	return func(job cron.Job) cron.Job {
+		return Job(logger, name, schedule, func(context.Context) { job.Run() })
+	}
+}
@@ -0,0 +1,64 @@
+//go:build !zax_disabled
+
+package zaxcron
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/robfig/cron/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+)
+
+func TestJob(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	var runIDs []string
+	job := Job(logs.GetZapLogger(), "cleanup", "@hourly", func(ctx context.Context) {
+		field, _ := zax.GetField(ctx, RunIDKey)
+		runIDs = append(runIDs, field.String)
+	})
+	job.Run()
+	job.Run()
+
+	require.Len(t, runIDs, 2)
+	assert.NotEqual(t, runIDs[0], runIDs[1], "every run gets its own ID")
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 4)
+	assert.Equal(t, "cron job started", entries[0].Message)
+	assert.Equal(t, "cron job finished", entries[1].Message)
+	fields := entries[1].ContextMap()
+	assert.Equal(t, "cleanup", fields[JobKey])
+	assert.Equal(t, "@hourly", fields[ScheduleKey])
+	assert.Equal(t, runIDs[0], fields[RunIDKey])
+	assert.Contains(t, fields, "duration")
+}
+
+func TestWrapperPanic(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	job := cron.NewChain(Wrapper(logs.GetZapLogger(), "broken", "@daily")).Then(cron.FuncJob(func() {
+		panic("boom")
+	}))
+
+	assert.NotPanics(t, job.Run)
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, "cron job panicked", entries[1].Message)
+	assert.Equal(t, "boom", entries[1].ContextMap()["panic"])
+	assert.Equal(t, "broken", entries[1].ContextMap()[JobKey])
+}
+
+func TestJobClock(t *testing.T) {
+	clock := zaxtest.NewClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	t.Cleanup(func() { zax.Configure(zax.WithClock(nil)) })
+	zax.Configure(zax.WithClock(clock))
+	logs := zaxtest.NewLogger(t)
+
+	Job(logs.GetZapLogger(), "cleanup", "@hourly", func(context.Context) { clock.Add(time.Minute) }).Run()
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 2)
+	assert.Equal(t, time.Minute, entries[1].ContextMap()["duration"])
+}
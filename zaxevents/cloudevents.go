@@ -0,0 +1,45 @@
+// Package zaxevents carries zax fields through event envelopes: CloudEvents
+// extension attributes and EventBridge detail metadata.
+package zaxevents
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/yuseferi/zax/v2"
+)
+
+// ExtensionName is the CloudEvents extension attribute holding the fields of
+// the producing context. Extension names are restricted to lower case letters
+// and digits, so fields travel serialized in a single attribute, as produced
+// by [zax.MarshalJSON], rather than one attribute per field.
+const ExtensionName = "zaxfields"
+
+// CloudEvent is the subset of the CloudEvents SDK event the package relies on;
+// *event.Event of github.com/cloudevents/sdk-go/v2 implements it.
+type CloudEvent interface {
+	SetExtension(name string, value interface{}) error
+	Extensions() map[string]interface{}
+}
+
+// InjectCloudEvent stores the fields of ctx in the [ExtensionName] extension
+// attribute of e. Events are left untouched if ctx holds no fields.
+func InjectCloudEvent(ctx context.Context, e CloudEvent) error {
+	metadata, err := zax.Enqueue(ctx, map[string]any(nil))
+	if err != nil || metadata == nil {
+		return err
+	}
+	return e.SetExtension(ExtensionName, metadata[zax.MetadataKey])
+}
+
+// ExtractCloudEvent appends the fields stored by [InjectCloudEvent] to ctx.
+func ExtractCloudEvent(ctx context.Context, e CloudEvent) (context.Context, error) {
+	fields, ok := e.Extensions()[ExtensionName]
+	if !ok {
+		return ctx, nil
+	}
+	if _, ok := fields.(string); !ok {
+		return ctx, fmt.Errorf("zaxevents: unexpected %T %s extension", fields, ExtensionName)
+	}
+	return zax.Dequeue(ctx, map[string]any{zax.MetadataKey: fields})
+}
@@ -0,0 +1,56 @@
+//go:build !zax_disabled
+
+package zaxevents
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// testEvent mimics the extension handling of the CloudEvents SDK event.
+type testEvent struct {
+	extensions map[string]interface{}
+}
+
+func (e *testEvent) SetExtension(name string, value interface{}) error {
+	if e.extensions == nil {
+		e.extensions = make(map[string]interface{})
+	}
+	e.extensions[name] = value
+	return nil
+}
+
+func (e *testEvent) Extensions() map[string]interface{} {
+	return e.extensions
+}
+
+func TestCloudEvent(t *testing.T) {
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc"), zap.Int("attempt", 2)})
+	e := &testEvent{}
+	require.NoError(t, InjectCloudEvent(ctx, e))
+	assert.Contains(t, e.extensions, ExtensionName)
+
+	consumerCtx, err := ExtractCloudEvent(context.Background(), e)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String("trace_id", "abc"), zap.Int64("attempt", 2)}, zax.GetAll(consumerCtx))
+}
+
+func TestCloudEventWithoutFields(t *testing.T) {
+	e := &testEvent{}
+	require.NoError(t, InjectCloudEvent(context.Background(), e))
+	assert.Empty(t, e.extensions)
+
+	ctx := context.Background()
+	consumerCtx, err := ExtractCloudEvent(ctx, e)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, consumerCtx)
+
+	e.extensions = map[string]interface{}{ExtensionName: 42}
+	_, err = ExtractCloudEvent(ctx, e)
+	assert.EqualError(t, err, "zaxevents: unexpected int zaxfields extension")
+}
@@ -0,0 +1,60 @@
+package zaxevents
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/yuseferi/zax/v2"
+)
+
+// MetadataKey is the member of EventBridge event details holding metadata,
+// following the common convention of separating it from the event data.
+const MetadataKey = "metadata"
+
+// InjectDetail stores the fields of ctx in the metadata of detail, the JSON
+// object sent as the Detail of an EventBridge PutEvents entry, under
+// [zax.MetadataKey]. Other members of detail and its metadata are kept.
+func InjectDetail(ctx context.Context, detail []byte) ([]byte, error) {
+	var object map[string]json.RawMessage
+	if len(detail) > 0 {
+		if err := json.Unmarshal(detail, &object); err != nil {
+			return detail, fmt.Errorf("zaxevents: event detail: %w", err)
+		}
+	}
+	var metadata map[string]any
+	if raw, ok := object[MetadataKey]; ok {
+		if err := json.Unmarshal(raw, &metadata); err != nil {
+			return detail, fmt.Errorf("zaxevents: event detail metadata: %w", err)
+		}
+	}
+	metadata, err := zax.Enqueue(ctx, metadata)
+	if err != nil || metadata == nil {
+		return detail, err
+	}
+	raw, err := json.Marshal(metadata)
+	if err != nil {
+		return detail, err
+	}
+	if object == nil {
+		object = make(map[string]json.RawMessage, 1)
+	}
+	object[MetadataKey] = raw
+	return json.Marshal(object)
+}
+
+// ExtractDetail appends the fields stored by [InjectDetail] to ctx; detail is
+// typically the Detail of an events.CloudWatchEvent received by a Lambda
+// function.
+func ExtractDetail(ctx context.Context, detail []byte) (context.Context, error) {
+	if len(detail) == 0 {
+		return ctx, nil
+	}
+	var object struct {
+		Metadata map[string]any `json:"metadata"`
+	}
+	if err := json.Unmarshal(detail, &object); err != nil {
+		return ctx, fmt.Errorf("zaxevents: event detail: %w", err)
+	}
+	return zax.Dequeue(ctx, object.Metadata)
+}
@@ -0,0 +1,47 @@
+//go:build !zax_disabled
+
+package zaxevents
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestDetail(t *testing.T) {
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc")})
+	detail, err := InjectDetail(ctx, []byte(`{"order":7,"metadata":{"source":"checkout"}}`))
+	require.NoError(t, err)
+
+	var object map[string]any
+	require.NoError(t, json.Unmarshal(detail, &object))
+	assert.Equal(t, float64(7), object["order"])
+	assert.Equal(t, "checkout", object[MetadataKey].(map[string]any)["source"])
+
+	event := events.CloudWatchEvent{DetailType: "order placed", Detail: detail}
+	consumerCtx, err := ExtractDetail(context.Background(), event.Detail)
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String("trace_id", "abc")}, zax.GetAll(consumerCtx))
+}
+
+func TestDetailWithoutFields(t *testing.T) {
+	detail, err := InjectDetail(context.Background(), []byte(`{"order":7}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"order":7}`, string(detail))
+
+	ctx := context.Background()
+	consumerCtx, err := ExtractDetail(ctx, detail)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, consumerCtx)
+
+	_, err = InjectDetail(ctx, []byte("["))
+	assert.Error(t, err)
+	_, err = ExtractDetail(ctx, []byte(`{"metadata":[]}`))
+	assert.Error(t, err)
+}
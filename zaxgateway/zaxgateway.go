@@ -0,0 +1,102 @@
+// Package zaxgateway keeps zax fields across grpc-gateway: the gateway maps
+// fields and selected HTTP headers to gRPC metadata, and the backend turns
+// that metadata back into fields, so gateway-fronted services keep end-to-end
+// correlation.
+package zaxgateway
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/yuseferi/zax/v2"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+// WithHeaders returns a ServeMux option annotating outgoing gRPC metadata as
+// [Annotator] does:
+//
+//	mux := runtime.NewServeMux(zaxgateway.WithHeaders(map[string]string{
+//		"X-Request-Id": "request_id",
+//	}))
+func WithHeaders(headers map[string]string) runtime.ServeMuxOption {
+	return runtime.WithMetadata(Annotator(headers))
+}
+
+// Annotator returns a grpc-gateway metadata annotator writing the fields of
+// the request context, and the value of headers found in the request as the
+// field key they are mapped to, to the metadata of the backend call. Header
+// values take precedence over fields of the same key. Metadata keys are case
+// insensitive, so keys extracted on the backend are lower case.
+func Annotator(headers map[string]string) func(context.Context, *http.Request) metadata.MD {
+	return func(ctx context.Context, r *http.Request) metadata.MD {
+		md := metadata.MD{}
+		zax.Inject(ctx, MetadataCarrier(md))
+		for header, key := range headers {
+			if value := r.Header.Get(header); value != "" {
+				MetadataCarrier(md).Set(zax.CarrierPrefix+key, value)
+			}
+		}
+		return md
+	}
+}
+
+// UnaryServerInterceptor returns a backend interceptor appending the fields
+// carried by incoming metadata, see [Annotator], to the handler context.
+func UnaryServerInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, _ *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		return handler(extract(ctx), req)
+	}
+}
+
+// StreamServerInterceptor is the streaming counterpart of
+// [UnaryServerInterceptor].
+func StreamServerInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, _ *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &serverStream{ServerStream: ss, ctx: extract(ss.Context())})
+	}
+}
+
+func extract(ctx context.Context) context.Context {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx
+	}
+	return zax.Extract(ctx, MetadataCarrier(md))
+}
+
+// serverStream overrides the context of a server stream.
+type serverStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *serverStream) Context() context.Context {
+	return s.ctx
+}
+
+// MetadataCarrier is a [zax.Carrier] backed by gRPC metadata.
+type MetadataCarrier metadata.MD
+
+// Get returns the first value associated with key.
+func (c MetadataCarrier) Get(key string) string {
+	if values := metadata.MD(c).Get(key); len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// Set stores the key/value pair, replacing existing values.
+func (c MetadataCarrier) Set(key string, value string) {
+	metadata.MD(c).Set(key, value)
+}
+
+// Keys lists the keys stored in the carrier.
+func (c MetadataCarrier) Keys() []string {
+	keys := make([]string, 0, len(c))
+	for key := range c {
+		keys = append(keys, key)
+	}
+	return keys
+}
@@ -0,0 +1,61 @@
+//go:build !zax_disabled
+
+package zaxgateway
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+)
+
+func TestAnnotator(t *testing.T) {
+	r := httptest.NewRequest("GET", "/v1/orders", nil)
+	r.Header.Set("X-Request-Id", "req-1")
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc"), zap.String("request_id", "stale")})
+
+	md := Annotator(map[string]string{"X-Request-Id": "request_id", "X-Tenant": "tenant_id"})(ctx, r)
+	assert.Equal(t, metadata.Pairs("zax-trace_id", "abc", "zax-request_id", "req-1"), md)
+}
+
+func TestUnaryServerInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("zax-request_id", "req-1", "authorization", "secret"))
+
+	var got []zap.Field
+	_, err := UnaryServerInterceptor()(ctx, nil, &grpc.UnaryServerInfo{}, func(ctx context.Context, _ any) (any, error) {
+		got = zax.GetAll(ctx)
+		return nil, nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String("request_id", "req-1")}, got)
+}
+
+type testStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s testStream) Context() context.Context { return s.ctx }
+
+func TestStreamServerInterceptor(t *testing.T) {
+	ctx := metadata.NewIncomingContext(context.Background(), metadata.Pairs("zax-request_id", "req-1"))
+
+	var got []zap.Field
+	err := StreamServerInterceptor()(nil, testStream{ctx: ctx}, &grpc.StreamServerInfo{}, func(_ any, ss grpc.ServerStream) error {
+		got = zax.GetAll(ss.Context())
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String("request_id", "req-1")}, got)
+}
+
+func TestWithHeaders(t *testing.T) {
+	assert.NotNil(t, runtime.NewServeMux(WithHeaders(map[string]string{"X-Request-Id": "request_id"})))
+}
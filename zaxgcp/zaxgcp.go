@@ -0,0 +1,101 @@
+// Package zaxgcp adds the trace and execution metadata of requests served by
+// Google Cloud serverless environments, such as Cloud Run and Cloud
+// Functions, to zax fields.
+package zaxgcp
+
+import (
+	"context"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// TraceHeader is the header carrying the trace context of requests.
+const TraceHeader = "X-Cloud-Trace-Context"
+
+// Keys of the fields Cloud Logging correlates with traces.
+const (
+	TraceKey        = "logging.googleapis.com/trace"
+	SpanIDKey       = "logging.googleapis.com/spanId"
+	TraceSampledKey = "logging.googleapis.com/trace_sampled"
+)
+
+// Keys of the fields holding the execution metadata.
+const (
+	ServiceKey       = "service"
+	RevisionKey      = "revision"
+	ConfigurationKey = "configuration"
+	FunctionKey      = "function"
+)
+
+// ParseTraceContext parses the value of a [TraceHeader] header, of the form
+// TRACE_ID/SPAN_ID;o=OPTIONS, where the span ID and options are optional.
+func ParseTraceContext(header string) (traceID, spanID string, sampled bool, ok bool) {
+	traceID, rest, _ := strings.Cut(header, "/")
+	if traceID == "" {
+		return "", "", false, false
+	}
+	spanID, options, _ := strings.Cut(rest, ";")
+	return traceID, spanID, options == "o=1", true
+}
+
+// TraceFields returns the fields correlating logs with the trace of header,
+// a [TraceHeader] value, in Cloud Logging. The trace is qualified by
+// projectID, as Cloud Logging expects, unless it is empty.
+func TraceFields(header, projectID string) []zap.Field {
+	traceID, spanID, sampled, ok := ParseTraceContext(header)
+	if !ok {
+		return nil
+	}
+	if projectID != "" {
+		traceID = "projects/" + projectID + "/traces/" + traceID
+	}
+	fields := []zap.Field{zap.String(TraceKey, traceID)}
+	if spanID != "" {
+		fields = append(fields, zap.String(SpanIDKey, spanID))
+	}
+	return append(fields, zap.Bool(TraceSampledKey, sampled))
+}
+
+// EnvFields returns the execution metadata set in the environment by Cloud
+// Run and Cloud Functions: the service, revision, configuration and function
+// target, as far as they are set. The environment is read once.
+var EnvFields = sync.OnceValue(func() []zap.Field {
+	var fields []zap.Field
+	for _, env := range []struct{ key, name string }{
+		{ServiceKey, "K_SERVICE"},
+		{RevisionKey, "K_REVISION"},
+		{ConfigurationKey, "K_CONFIGURATION"},
+		{FunctionKey, "FUNCTION_TARGET"},
+	} {
+		if value := os.Getenv(env.name); value != "" {
+			fields = append(fields, zap.String(env.key, value))
+		}
+	}
+	return fields
+})
+
+// FromRequest returns the context of r with the fields of its trace, see
+// [TraceFields], and the execution metadata, see [EnvFields].
+func FromRequest(r *http.Request, projectID string) context.Context {
+	fields := append(TraceFields(r.Header.Get(TraceHeader), projectID), EnvFields()...)
+	return zax.Append(r.Context(), fields)
+}
+
+// Middleware returns middleware serving requests with the context returned
+// by [FromRequest]. projectID defaults to the GOOGLE_CLOUD_PROJECT
+// environment variable.
+func Middleware(projectID string) func(http.Handler) http.Handler {
+	if projectID == "" {
+		projectID = os.Getenv("GOOGLE_CLOUD_PROJECT")
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			next.ServeHTTP(w, r.WithContext(FromRequest(r, projectID)))
+		})
+	}
+}
@@ -0,0 +1,53 @@
+//go:build !zax_disabled
+
+package zaxgcp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestParseTraceContext(t *testing.T) {
+	tests := map[string]struct {
+		header           string
+		traceID, spanID  string
+		sampled, correct bool
+	}{
+		"full":       {"105445aa7843bc8bf206b12000100000/1;o=1", "105445aa7843bc8bf206b12000100000", "1", true, true},
+		"unsampled":  {"105445aa7843bc8bf206b12000100000/1;o=0", "105445aa7843bc8bf206b12000100000", "1", false, true},
+		"trace only": {"105445aa7843bc8bf206b12000100000", "105445aa7843bc8bf206b12000100000", "", false, true},
+		"empty":      {"", "", "", false, false},
+	}
+	for name, tt := range tests {
+		t.Run(name, func(t *testing.T) {
+			traceID, spanID, sampled, ok := ParseTraceContext(tt.header)
+			assert.Equal(t, tt.correct, ok)
+			assert.Equal(t, tt.traceID, traceID)
+			assert.Equal(t, tt.spanID, spanID)
+			assert.Equal(t, tt.sampled, sampled)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Setenv("GOOGLE_CLOUD_PROJECT", "project")
+	var fields []zap.Field
+	handler := Middleware("")(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		fields = zax.GetAll(r.Context())
+	}))
+
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(TraceHeader, "abc/123;o=1")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.Equal(t, append([]zap.Field{
+		zap.String(TraceKey, "projects/project/traces/abc"),
+		zap.String(SpanIDKey, "123"),
+		zap.Bool(TraceSampledKey, true),
+	}, EnvFields()...), fields)
+}
@@ -0,0 +1,62 @@
+// Package zaxgqlgen provides a gqlgen extension recording GraphQL operations
+// and resolver paths as zax fields, so that GraphQL logs can be sliced by
+// operation.
+package zaxgqlgen
+
+import (
+	"context"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields set by [Extension].
+const (
+	OperationNameKey = "graphql_operation"
+	OperationTypeKey = "graphql_operation_type"
+	PathKey          = "graphql_path"
+)
+
+// Extension is a gqlgen handler extension appending the name and type of
+// each operation to the zax fields of its context, and the path of each
+// resolver to the context of the resolver:
+//
+//	srv := handler.NewDefaultServer(schema)
+//	srv.Use(zaxgqlgen.Extension{})
+type Extension struct{}
+
+var (
+	_ graphql.HandlerExtension     = Extension{}
+	_ graphql.OperationInterceptor = Extension{}
+	_ graphql.FieldInterceptor     = Extension{}
+)
+
+// ExtensionName implements [graphql.HandlerExtension].
+func (Extension) ExtensionName() string {
+	return "ZaxFields"
+}
+
+// Validate implements [graphql.HandlerExtension].
+func (Extension) Validate(graphql.ExecutableSchema) error {
+	return nil
+}
+
+// InterceptOperation implements [graphql.OperationInterceptor].
+func (Extension) InterceptOperation(ctx context.Context, next graphql.OperationHandler) graphql.ResponseHandler {
+	oc := graphql.GetOperationContext(ctx)
+	fields := []zap.Field{zap.String(OperationNameKey, oc.OperationName)}
+	if oc.Operation != nil {
+		fields = append(fields, zap.String(OperationTypeKey, string(oc.Operation.Operation)))
+	}
+	return next(zax.Append(ctx, fields))
+}
+
+// InterceptField implements [graphql.FieldInterceptor]. Only resolvers get a
+// path field; trivial fields would pay for it on every value.
+func (Extension) InterceptField(ctx context.Context, next graphql.Resolver) (any, error) {
+	if fc := graphql.GetFieldContext(ctx); fc != nil && fc.IsResolver {
+		ctx = zax.Append(ctx, []zap.Field{zap.String(PathKey, fc.Path().String())})
+	}
+	return next(ctx)
+}
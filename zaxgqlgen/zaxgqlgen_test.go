@@ -0,0 +1,46 @@
+//go:build !zax_disabled
+
+package zaxgqlgen
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/99designs/gqlgen/graphql"
+	"github.com/99designs/gqlgen/graphql/handler/testserver"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestExtension(t *testing.T) {
+	srv := testserver.New()
+	srv.AddTransport(transport.POST{})
+	// The test server resolves fields without generated resolvers.
+	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (any, error) {
+		graphql.GetFieldContext(ctx).IsResolver = true
+		return next(ctx)
+	})
+	srv.Use(Extension{})
+	var fields []zap.Field
+	srv.AroundFields(func(ctx context.Context, next graphql.Resolver) (any, error) {
+		fields = zax.GetAll(ctx)
+		return next(ctx)
+	})
+
+	r := httptest.NewRequest(http.MethodPost, "/graphql", strings.NewReader(`{"query":"query GetName { name }","operationName":"GetName"}`))
+	r.Header.Set("Content-Type", "application/json")
+	w := httptest.NewRecorder()
+	srv.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusOK, w.Code, w.Body.String())
+	assert.Equal(t, []zap.Field{
+		zap.String(PathKey, "name"),
+		zap.String(OperationNameKey, "GetName"),
+		zap.String(OperationTypeKey, "query"),
+	}, fields)
+}
@@ -0,0 +1,67 @@
+// Package zaxgroup wraps errgroup so that every goroutine of a group logs
+// with the fields of the group's context, plus the name of its task.
+package zaxgroup
+
+import (
+	"context"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"golang.org/x/sync/errgroup"
+)
+
+// TaskKey is the zap field key holding the name of the task a goroutine runs.
+const TaskKey = "task"
+
+// Group is an [errgroup.Group] whose goroutines receive the group's context,
+// with its zax fields, extended by a [TaskKey] field.
+type Group struct {
+	group *errgroup.Group
+	ctx   context.Context
+}
+
+// WithContext returns a new Group and a context derived from ctx, as
+// [errgroup.WithContext] does. The derived context keeps the fields of ctx.
+func WithContext(ctx context.Context) (*Group, context.Context) {
+	group, ctx := errgroup.WithContext(ctx)
+	return &Group{group: group, ctx: ctx}, ctx
+}
+
+// Go calls fn in a new goroutine with the group's context and a task field
+// set to task, as [errgroup.Group.Go] does.
+func (g *Group) Go(task string, fn func(ctx context.Context) error) {
+	ctx := TaskContext(g.ctx, task)
+	g.group.Go(func() error {
+		return fn(ctx)
+	})
+}
+
+// TryGo is like Go, but only starts fn if the number of active goroutines is
+// below the limit; see [errgroup.Group.TryGo].
+func (g *Group) TryGo(task string, fn func(ctx context.Context) error) bool {
+	ctx := TaskContext(g.ctx, task)
+	return g.group.TryGo(func() error {
+		return fn(ctx)
+	})
+}
+
+// SetLimit limits the number of active goroutines; see
+// [errgroup.Group.SetLimit].
+func (g *Group) SetLimit(n int) {
+	g.group.SetLimit(n)
+}
+
+// Wait blocks until all goroutines have returned, then returns the first
+// non-nil error, if any.
+func (g *Group) Wait() error {
+	return g.group.Wait()
+}
+
+// TaskContext returns ctx with a [TaskKey] field set to task. It is the
+// building block of Group, for code that uses errgroup.WithContext directly:
+//
+//	g, ctx := errgroup.WithContext(ctx)
+//	g.Go(func() error { return fetch(zaxgroup.TaskContext(ctx, "fetch")) })
+func TaskContext(ctx context.Context, task string) context.Context {
+	return zax.Append(ctx, []zap.Field{zap.String(TaskKey, task)})
+}
@@ -0,0 +1,52 @@
+//go:build !zax_disabled
+
+package zaxgroup
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestGroup(t *testing.T) {
+	parent := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "trace")})
+	group, ctx := WithContext(parent)
+	group.SetLimit(2)
+
+	var (
+		mu    sync.Mutex
+		tasks = map[string][]zap.Field{}
+	)
+	record := func(ctx context.Context) error {
+		task, _ := zax.GetField(ctx, TaskKey)
+		mu.Lock()
+		defer mu.Unlock()
+		tasks[task.String] = zax.GetAll(ctx)
+		return nil
+	}
+	group.Go("fetch", record)
+	group.Go("parse", record)
+	assert.NoError(t, group.Wait())
+
+	assert.Equal(t, []zap.Field{zap.String(TaskKey, "fetch"), zap.String("trace_id", "trace")}, tasks["fetch"])
+	assert.Equal(t, []zap.Field{zap.String(TaskKey, "parse"), zap.String("trace_id", "trace")}, tasks["parse"])
+	assert.Equal(t, zax.GetAll(parent), zax.GetAll(ctx))
+}
+
+func TestGroupError(t *testing.T) {
+	group, ctx := WithContext(context.Background())
+	failure := errors.New("failure")
+
+	group.Go("failing", func(context.Context) error { return failure })
+	assert.True(t, group.TryGo("waiting", func(ctx context.Context) error {
+		<-ctx.Done()
+		return nil
+	}))
+	assert.ErrorIs(t, group.Wait(), failure)
+	assert.Error(t, ctx.Err())
+}
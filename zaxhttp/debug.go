@@ -0,0 +1,41 @@
+package zaxhttp
+
+import (
+	"net/http"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// DebugHeader is the request header flagging a request for debugging; its
+// value names the debug session.
+const DebugHeader = "X-Debug-Session"
+
+// DebugSessionKey is the key of the field holding the debug session of
+// requests flagged by [DebugHeader].
+const DebugSessionKey = "debug_session"
+
+// Debug returns middleware letting individual production requests be logged
+// verbosely on demand. Requests with a [DebugHeader] that authorize accepts
+// get a context logging at debug level, see [zax.WithLevel], and holding the
+// value of the header under [DebugSessionKey], so that the lines of a session
+// can be found. The header of other requests is ignored.
+//
+// Debug logs may be large and reveal more than usual, so authorize must make
+// sure the header comes from a trusted party, for example by checking a
+// signature or the authenticated principal; a nil authorize rejects every
+// request.
+func Debug(authorize func(*http.Request) bool) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			session := r.Header.Get(DebugHeader)
+			if session != "" && authorize != nil && authorize(r) {
+				ctx := zax.WithLevel(r.Context(), zapcore.DebugLevel)
+				ctx = zax.Append(ctx, []zap.Field{zap.String(DebugSessionKey, session)})
+				r = r.WithContext(ctx)
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,49 @@
+//go:build !zax_disabled
+
+package zaxhttp
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestDebug(t *testing.T) {
+	core, logs := observer.New(zapcore.InfoLevel)
+	logger := zap.New(core)
+	authorize := func(r *http.Request) bool { return r.Header.Get("Authorization") == "Bearer support" }
+	handler := Debug(authorize)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		zax.Logger(r.Context(), logger).Debug("verbose")
+	}))
+
+	for _, authorization := range []string{"Bearer support", "Bearer someone"} {
+		r := httptest.NewRequest(http.MethodGet, "/", nil)
+		r.Header.Set(DebugHeader, "ticket-42")
+		r.Header.Set("Authorization", authorization)
+		handler.ServeHTTP(httptest.NewRecorder(), r)
+	}
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+
+	entries := logs.AllUntimed()
+	require.Len(t, entries, 1, "only the authorized request is logged at debug level")
+	assert.Equal(t, "ticket-42", entries[0].ContextMap()[DebugSessionKey])
+}
+
+func TestDebugWithoutAuthorize(t *testing.T) {
+	var debugging bool
+	handler := Debug(nil)(http.HandlerFunc(func(_ http.ResponseWriter, r *http.Request) {
+		_, debugging = zax.LevelFrom(r.Context())
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set(DebugHeader, "ticket-42")
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	assert.False(t, debugging)
+}
@@ -0,0 +1,55 @@
+package zaxhttp
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap/zapcore"
+)
+
+// DefaultEchoHeaders maps the correlation fields of the zax keys to the
+// response headers [EchoHeaders] writes them to by default.
+var DefaultEchoHeaders = map[string]string{
+	zax.RequestIDKey.Name(): "X-Request-Id",
+	zax.TraceIDKey.Name():   "X-Trace-Id",
+}
+
+// EchoHeaders returns middleware writing the fields of the request context
+// with the keys of headers to the response header they are mapped to, so that
+// clients and support tooling can reference a request without access to the
+// logs. A nil headers map means [DefaultEchoHeaders].
+//
+// Headers are written before calling the wrapped handler, so the middleware
+// must run after the one setting the fields; handlers setting fields
+// themselves can call [Echo].
+func EchoHeaders(headers map[string]string) func(http.Handler) http.Handler {
+	if headers == nil {
+		headers = DefaultEchoHeaders
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			Echo(r.Context(), w.Header(), headers)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// Echo writes the fields of ctx with the keys of headers to the header h, as
+// [EchoHeaders] does. Fields missing from ctx are skipped.
+func Echo(ctx context.Context, h http.Header, headers map[string]string) {
+	for key, header := range headers {
+		field, ok := zax.GetField(ctx, key)
+		if !ok {
+			continue
+		}
+		if field.Type == zapcore.StringType {
+			h.Set(header, field.String)
+			continue
+		}
+		if value := zax.Value(field); value != nil {
+			h.Set(header, fmt.Sprint(value))
+		}
+	}
+}
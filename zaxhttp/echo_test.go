@@ -0,0 +1,36 @@
+//go:build !zax_disabled
+
+package zaxhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestEchoHeaders(t *testing.T) {
+	handler := EchoHeaders(nil)(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+
+	ctx := zax.SetRequestID(context.Background(), "req-1")
+	r := httptest.NewRequest(http.MethodGet, "/", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, "req-1", w.Header().Get("X-Request-Id"))
+	assert.NotContains(t, w.Header(), "X-Trace-Id")
+}
+
+func TestEcho(t *testing.T) {
+	ctx := zax.Set(context.Background(), []zap.Field{zap.Int("shard", 7), zap.String("tenant_id", "t-1")})
+	h := http.Header{}
+	Echo(ctx, h, map[string]string{"shard": "X-Shard", "tenant_id": "X-Tenant", "missing": "X-Missing"})
+
+	assert.Equal(t, http.Header{"X-Shard": {"7"}, "X-Tenant": {"t-1"}}, h)
+}
@@ -0,0 +1,149 @@
+package zaxhttp
+
+import (
+	"context"
+	"crypto/tls"
+	"net/http"
+	"net/http/httptrace"
+	"sync"
+	"time"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// Keys of the fields holding the timings of outbound requests.
+const (
+	DNSKey        = "http_dns"
+	ConnectKey    = "http_connect"
+	TLSKey        = "http_tls"
+	FirstByteKey  = "http_first_byte"
+	ConnReusedKey = "http_conn_reused"
+)
+
+// Timings are the connection-level timings of an outbound request, measured
+// with [net/http/httptrace].
+type Timings struct {
+	clock      zapcore.Clock
+	mu         sync.Mutex
+	start      time.Time
+	dnsStart   time.Time
+	dns        time.Duration
+	dials      map[string]time.Time
+	connect    time.Duration
+	tlsStart   time.Time
+	tls        time.Duration
+	firstByte  time.Duration
+	connReused bool
+}
+
+// WithTimings returns ctx tracing the requests made with it, along with the
+// timings they record, as measured by [zax.Clock]. Requests reusing a
+// connection skip the DNS, connect and TLS phases.
+//
+//	ctx, timings := zaxhttp.WithTimings(ctx)
+//	resp, err := client.Do(req.WithContext(ctx))
+//	ctx = zax.Append(ctx, timings.Fields())
+func WithTimings(ctx context.Context) (context.Context, *Timings) {
+	clock := zax.Clock()
+	t := &Timings{clock: clock, start: clock.Now()}
+	trace := &httptrace.ClientTrace{
+		DNSStart:     func(httptrace.DNSStartInfo) { t.mark(&t.dnsStart) },
+		DNSDone:      func(httptrace.DNSDoneInfo) { t.measure(&t.dns, &t.dnsStart) },
+		ConnectStart: func(network, addr string) { t.startDial(network + "/" + addr) },
+		ConnectDone:  func(network, addr string, err error) { t.endDial(network+"/"+addr, err) },
+		TLSHandshakeStart: func() {
+			t.mark(&t.tlsStart)
+		},
+		TLSHandshakeDone: func(tls.ConnectionState, error) { t.measure(&t.tls, &t.tlsStart) },
+		GotConn: func(info httptrace.GotConnInfo) {
+			t.mu.Lock()
+			defer t.mu.Unlock()
+			t.connReused = info.Reused
+		},
+		GotFirstResponseByte: func() { t.measure(&t.firstByte, &t.start) },
+	}
+	return httptrace.WithClientTrace(ctx, trace), t
+}
+
+func (t *Timings) mark(at *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*at = t.clock.Now()
+}
+
+// startDial records the start of the dial of addr. Dials are timed
+// separately, as several race each other with happy eyeballs.
+func (t *Timings) startDial(addr string) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.dials == nil {
+		t.dials = make(map[string]time.Time, 2)
+	}
+	t.dials[addr] = t.clock.Now()
+}
+
+// endDial records the connect time of the dial of addr, unless it failed, so
+// that the dial losing a race doesn't override the one that connected.
+func (t *Timings) endDial(addr string, err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	start, ok := t.dials[addr]
+	delete(t.dials, addr)
+	if ok && err == nil {
+		t.connect = t.clock.Now().Sub(start)
+	}
+}
+
+// measure sets d to the time elapsed since the start of its phase, read under
+// the lock since dials race each other, e.g. for happy eyeballs.
+func (t *Timings) measure(d *time.Duration, since *time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	*d = t.clock.Now().Sub(*since)
+}
+
+// Fields returns the recorded timings as fields, leaving out the phases that
+// didn't happen.
+func (t *Timings) Fields() []zap.Field {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	fields := make([]zap.Field, 0, 5)
+	for _, phase := range []struct {
+		key string
+		d   time.Duration
+	}{{DNSKey, t.dns}, {ConnectKey, t.connect}, {TLSKey, t.tls}, {FirstByteKey, t.firstByte}} {
+		if phase.d > 0 {
+			fields = append(fields, zap.Duration(phase.key, phase.d))
+		}
+	}
+	return append(fields, zap.Bool(ConnReusedKey, t.connReused))
+}
+
+// Transport returns a round tripper logging, through logger, every request
+// sent through base, or [http.DefaultTransport] if nil, with its timings and
+// the zax fields of its context.
+func Transport(base http.RoundTripper, logger *zap.Logger) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return roundTripper(func(r *http.Request) (*http.Response, error) {
+		ctx, timings := WithTimings(r.Context())
+		resp, err := base.RoundTrip(r.WithContext(ctx))
+		fields := append(timings.Fields(), zap.String("method", r.Method), zap.String("url", r.URL.Redacted()))
+		if err != nil {
+			fields = append(fields, zap.Error(err))
+		} else {
+			fields = append(fields, zap.Int("status", resp.StatusCode))
+		}
+		zax.Logger(r.Context(), logger).Info("outbound request", fields...)
+		return resp, err
+	})
+}
+
+type roundTripper func(*http.Request) (*http.Response, error)
+
+func (f roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
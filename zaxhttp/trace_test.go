@@ -0,0 +1,123 @@
+//go:build !zax_disabled
+
+package zaxhttp
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/http/httptrace"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+	"go.uber.org/zap"
+)
+
+func TestWithTimings(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	ctx, timings := WithTimings(context.Background())
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL, nil)
+	require.NoError(t, err)
+	resp, err := server.Client().Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	ctx = zax.Append(ctx, timings.Fields())
+	keys := zax.WriteEvent{Fields: zax.GetAll(ctx)}.Keys()
+	assert.Contains(t, keys, ConnectKey)
+	assert.Contains(t, keys, FirstByteKey)
+	assert.NotContains(t, keys, TLSKey, "no TLS handshake over plain HTTP")
+	reused, _ := zax.GetField(ctx, ConnReusedKey)
+	assert.Equal(t, zap.Bool(ConnReusedKey, false), reused)
+}
+
+func TestWithTimingsParallelDials(t *testing.T) {
+	ctx, timings := WithTimings(context.Background())
+	trace := httptrace.ContextClientTrace(ctx)
+	var wg sync.WaitGroup
+	for _, network := range []string{"tcp4", "tcp6"} {
+		wg.Add(1)
+		go func(network string) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				trace.ConnectStart(network, "localhost:80")
+				trace.ConnectDone(network, "localhost:80", nil)
+			}
+		}(network)
+	}
+	wg.Wait()
+
+	keys := zax.WriteEvent{Fields: timings.Fields()}.Keys()
+	assert.Contains(t, keys, ConnectKey)
+}
+
+func TestWithTimingsFailedDial(t *testing.T) {
+	clock := zaxtest.NewClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	t.Cleanup(func() { zax.Configure(zax.WithClock(nil)) })
+	zax.Configure(zax.WithClock(clock))
+
+	ctx, timings := WithTimings(context.Background())
+	trace := httptrace.ContextClientTrace(ctx)
+	trace.ConnectStart("tcp6", "[::1]:80")
+	clock.Add(time.Second)
+	trace.ConnectStart("tcp4", "127.0.0.1:80")
+	clock.Add(time.Second)
+	trace.ConnectDone("tcp4", "127.0.0.1:80", nil)
+	clock.Add(time.Second)
+	trace.ConnectDone("tcp6", "[::1]:80", errors.New("connection refused"))
+
+	assert.Contains(t, timings.Fields(), zap.Duration(ConnectKey, time.Second), "the failed dial is ignored")
+}
+
+func TestWithTimingsClock(t *testing.T) {
+	clock := zaxtest.NewClock(time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC))
+	t.Cleanup(func() { zax.Configure(zax.WithClock(nil)) })
+	zax.Configure(zax.WithClock(clock))
+
+	ctx, timings := WithTimings(context.Background())
+	trace := httptrace.ContextClientTrace(ctx)
+	trace.ConnectStart("tcp", "localhost:80")
+	clock.Add(time.Second)
+	trace.ConnectDone("tcp", "localhost:80", nil)
+	clock.Add(time.Second)
+	trace.GotFirstResponseByte()
+
+	fields := timings.Fields()
+	assert.Contains(t, fields, zap.Duration(ConnectKey, time.Second))
+	assert.Contains(t, fields, zap.Duration(FirstByteKey, 2*time.Second))
+}
+
+func TestTransport(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusTeapot)
+	}))
+	defer server.Close()
+
+	logs := zaxtest.NewLogger(t)
+	client := &http.Client{Transport: Transport(nil, logs.GetZapLogger())}
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "trace")})
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, server.URL+"/brew", nil)
+	require.NoError(t, err)
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	require.NoError(t, resp.Body.Close())
+
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "outbound request", entries[0].Message)
+	assert.Equal(t, int64(http.StatusTeapot), fields["status"])
+	assert.Equal(t, server.URL+"/brew", fields["url"])
+	assert.Equal(t, "trace", fields["trace_id"])
+	assert.Contains(t, fields, FirstByteKey)
+}
@@ -0,0 +1,103 @@
+// Package zaxhttp integrates zax with net/http: middleware and round trippers
+// logging with the zax fields of request contexts.
+package zaxhttp
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"runtime/debug"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Recover returns middleware recovering from panics in the wrapped handler.
+// The panic value and stack are logged through logger along with the fields
+// of the request context, so that crash logs are correlated to the request
+// that caused them, and the client receives a 500 unless the handler already
+// wrote a response.
+//
+// Like net/http, it lets [http.ErrAbortHandler] abort the request silently.
+func Recover(logger *zap.Logger) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			rw := &responseWriter{ResponseWriter: w}
+			defer func() {
+				p := recover()
+				if p == nil {
+					return
+				}
+				if err, ok := p.(error); ok && errors.Is(err, http.ErrAbortHandler) {
+					panic(p)
+				}
+				zax.Logger(r.Context(), logger).Error("recovered from panic in HTTP handler",
+					zap.Any("panic", p),
+					zap.ByteString("stack", debug.Stack()),
+					zap.String("method", r.Method),
+					zap.String("path", r.URL.Path),
+				)
+				if !rw.wroteHeader {
+					http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+				}
+			}()
+			next.ServeHTTP(rw, r)
+		})
+	}
+}
+
+// responseWriter records whether the response header was written.
+type responseWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *responseWriter) WriteHeader(code int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(code)
+}
+
+func (w *responseWriter) Write(b []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(b)
+}
+
+// Flush flushes the underlying writer, if it is an [http.Flusher], so that
+// handlers can stream through the middleware.
+func (w *responseWriter) Flush() {
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		w.wroteHeader = true
+		f.Flush()
+	}
+}
+
+// Hijack hijacks the connection of the underlying writer, if it is an
+// [http.Hijacker], for instance to upgrade to WebSocket.
+func (w *responseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	h, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, http.ErrNotSupported
+	}
+	conn, rw, err := h.Hijack()
+	if err == nil {
+		w.wroteHeader = true
+	}
+	return conn, rw, err
+}
+
+// ReadFrom lets the underlying writer, if it is an [io.ReaderFrom], copy
+// from src, such as with sendfile for files.
+func (w *responseWriter) ReadFrom(src io.Reader) (int64, error) {
+	w.wroteHeader = true
+	if rf, ok := w.ResponseWriter.(io.ReaderFrom); ok {
+		return rf.ReadFrom(src)
+	}
+	return io.Copy(w.ResponseWriter, src)
+}
+
+// Unwrap lets [http.ResponseController] reach the underlying writer.
+func (w *responseWriter) Unwrap() http.ResponseWriter {
+	return w.ResponseWriter
+}
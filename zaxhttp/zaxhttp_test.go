@@ -0,0 +1,82 @@
+//go:build !zax_disabled
+
+package zaxhttp
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+	"go.uber.org/zap"
+)
+
+func TestRecover(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	handler := Recover(logs.GetZapLogger())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic("boom")
+	}))
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "trace")})
+	r := httptest.NewRequest(http.MethodGet, "/crash", nil).WithContext(ctx)
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, r)
+
+	assert.Equal(t, http.StatusInternalServerError, w.Code)
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "boom", fields["panic"])
+	assert.Equal(t, "trace", fields["trace_id"])
+	assert.Equal(t, "/crash", fields["path"])
+	assert.Contains(t, fields["stack"], "zaxhttp")
+}
+
+func TestRecoverWrittenResponse(t *testing.T) {
+	handler := Recover(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusAccepted)
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.Equal(t, http.StatusAccepted, w.Code)
+}
+
+func TestRecoverFlush(t *testing.T) {
+	handler := Recover(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		flusher, ok := w.(http.Flusher)
+		require.True(t, ok, "the response writer must remain a flusher")
+		_, _ = w.Write([]byte("chunk"))
+		flusher.Flush()
+		panic("boom")
+	}))
+
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/", nil))
+	assert.True(t, w.Flushed)
+	assert.Equal(t, "chunk", w.Body.String(), "no error is written after a flush")
+}
+
+func TestRecoverHijackNotSupported(t *testing.T) {
+	handler := Recover(zap.NewNop())(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _, err := w.(http.Hijacker).Hijack()
+		assert.ErrorIs(t, err, http.ErrNotSupported)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+}
+
+func TestRecoverAbortHandler(t *testing.T) {
+	handler := Recover(zap.NewNop())(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		panic(http.ErrAbortHandler)
+	}))
+
+	assert.PanicsWithValue(t, http.ErrAbortHandler, func() {
+		handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/", nil))
+	})
+}
@@ -0,0 +1,70 @@
+// Package zaxk8s exposes the metadata of the Kubernetes pod running the
+// process, as published by the downward API, as zax fields.
+//
+// The downward API publishes metadata through environment variables declared
+// in the pod spec, for example:
+//
+//	env:
+//	  - name: POD_NAME
+//	    valueFrom:
+//	      fieldRef:
+//	        fieldPath: metadata.name
+package zaxk8s
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields holding the pod metadata.
+const (
+	PodKey       = "k8s_pod"
+	NamespaceKey = "k8s_namespace"
+	NodeKey      = "k8s_node"
+	PodIPKey     = "k8s_pod_ip"
+)
+
+// Env maps the keys of the fields to the environment variables they are read
+// from. Change it before the first call to [Fields] to match the pod spec.
+var Env = []struct{ Key, Var string }{
+	{PodKey, "POD_NAME"},
+	{NamespaceKey, "POD_NAMESPACE"},
+	{NodeKey, "NODE_NAME"},
+	{PodIPKey, "POD_IP"},
+}
+
+var fields = sync.OnceValue(readFields)
+
+// readFields reads the pod metadata from the environment.
+func readFields() []zap.Field {
+	var fields []zap.Field
+	for _, env := range Env {
+		if value := os.Getenv(env.Var); value != "" {
+			fields = append(fields, zap.String(env.Key, value))
+		}
+	}
+	return fields
+}
+
+// Fields returns the pod metadata set in the environment, read once; see
+// [Env].
+func Fields() []zap.Field {
+	return fields()
+}
+
+// Install returns ctx with the pod metadata appended to its fields.
+func Install(ctx context.Context) context.Context {
+	return zax.Append(ctx, Fields())
+}
+
+// Option returns a logger option attaching the pod metadata to every entry,
+// whatever its context:
+//
+//	logger = logger.WithOptions(zaxk8s.Option())
+func Option() zap.Option {
+	return zap.Fields(Fields()...)
+}
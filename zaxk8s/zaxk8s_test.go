@@ -0,0 +1,34 @@
+//go:build !zax_disabled
+
+package zaxk8s
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+	"go.uber.org/zap"
+)
+
+func TestFields(t *testing.T) {
+	t.Setenv("POD_NAME", "api-7d4b9")
+	t.Setenv("POD_NAMESPACE", "prod")
+	t.Setenv("NODE_NAME", "")
+	t.Setenv("POD_IP", "10.0.0.7")
+	fields = sync.OnceValue(readFields)
+
+	expected := []zap.Field{
+		zap.String(PodKey, "api-7d4b9"),
+		zap.String(NamespaceKey, "prod"),
+		zap.String(PodIPKey, "10.0.0.7"),
+	}
+	assert.Equal(t, expected, Fields())
+	assert.Equal(t, expected, zax.GetAll(Install(context.Background())))
+
+	logs := zaxtest.NewLogger(t)
+	logs.GetZapLogger().WithOptions(Option()).Info("started")
+	logs.AssertLogEntryKeyExist(t, PodKey)
+}
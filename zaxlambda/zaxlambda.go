@@ -0,0 +1,53 @@
+// Package zaxlambda adds the metadata of AWS Lambda invocations to zax
+// fields.
+package zaxlambda
+
+import (
+	"context"
+	"sync/atomic"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields holding the invocation metadata.
+const (
+	RequestIDKey       = "aws_request_id"
+	FunctionARNKey     = "function_arn"
+	FunctionNameKey    = "function_name"
+	FunctionVersionKey = "function_version"
+	ColdStartKey       = "cold_start"
+)
+
+// warm is set once the first invocation of the process got its fields.
+var warm atomic.Bool
+
+// FromContext returns ctx with fields holding the request ID and invoked
+// function ARN found in the Lambda context of ctx, the function name and
+// version, and whether the invocation is the first of the process, a cold
+// start. Without a Lambda context, only the function fields are added.
+func FromContext(ctx context.Context) context.Context {
+	fields := make([]zap.Field, 0, 5)
+	if lc, ok := lambdacontext.FromContext(ctx); ok {
+		fields = append(fields,
+			zap.String(RequestIDKey, lc.AwsRequestID),
+			zap.String(FunctionARNKey, lc.InvokedFunctionArn),
+		)
+	}
+	fields = append(fields,
+		zap.String(FunctionNameKey, lambdacontext.FunctionName),
+		zap.String(FunctionVersionKey, lambdacontext.FunctionVersion),
+		zap.Bool(ColdStartKey, !warm.Swap(true)),
+	)
+	return zax.Append(ctx, fields)
+}
+
+// Wrap returns handler receiving a context extended by [FromContext]:
+//
+//	lambda.Start(zaxlambda.Wrap(handle))
+func Wrap[In, Out any](handler func(context.Context, In) (Out, error)) func(context.Context, In) (Out, error) {
+	return func(ctx context.Context, in In) (Out, error) {
+		return handler(FromContext(ctx), in)
+	}
+}
@@ -0,0 +1,42 @@
+//go:build !zax_disabled
+
+package zaxlambda
+
+import (
+	"context"
+	"testing"
+
+	"github.com/aws/aws-lambda-go/lambdacontext"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestWrap(t *testing.T) {
+	warm.Store(false)
+	lambdacontext.FunctionName, lambdacontext.FunctionVersion = "resize", "7"
+	ctx := lambdacontext.NewContext(context.Background(), &lambdacontext.LambdaContext{
+		AwsRequestID:       "request",
+		InvokedFunctionArn: "arn:aws:lambda:eu-west-1:123456789012:function:resize",
+	})
+
+	var fields []zap.Field
+	handler := Wrap(func(ctx context.Context, in string) (string, error) {
+		fields = zax.GetAll(ctx)
+		return in, nil
+	})
+	out, err := handler(ctx, "event")
+	require.NoError(t, err)
+	assert.Equal(t, "event", out)
+	assert.Equal(t, []zap.Field{
+		zap.String(RequestIDKey, "request"),
+		zap.String(FunctionARNKey, "arn:aws:lambda:eu-west-1:123456789012:function:resize"),
+		zap.String(FunctionNameKey, "resize"),
+		zap.String(FunctionVersionKey, "7"),
+		zap.Bool(ColdStartKey, true),
+	}, fields)
+
+	cold, _ := zax.GetField(FromContext(context.Background()), ColdStartKey)
+	assert.Equal(t, zap.Bool(ColdStartKey, false), cold)
+}
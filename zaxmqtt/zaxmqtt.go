@@ -0,0 +1,71 @@
+// Package zaxmqtt carries zax fields through MQTT v5 user properties, using
+// the eclipse/paho.golang client, so that device flows can be correlated
+// across publishers and subscribers.
+package zaxmqtt
+
+import (
+	"context"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// TopicKey is the key of the field holding the topic of received messages.
+const TopicKey = "mqtt_topic"
+
+// Inject writes the fields stored in ctx to the user properties of p, see
+// [zax.Inject].
+func Inject(ctx context.Context, p *paho.Publish) {
+	if p.Properties == nil {
+		p.Properties = &paho.PublishProperties{}
+	}
+	zax.Inject(ctx, (*carrier)(&p.Properties.User))
+}
+
+// Extract appends the fields written by [Inject] to the user properties of p
+// to ctx, see [zax.Extract].
+func Extract(ctx context.Context, p *paho.Publish) context.Context {
+	if p.Properties == nil {
+		return ctx
+	}
+	return zax.Extract(ctx, (*carrier)(&p.Properties.User))
+}
+
+// Handler returns a message handler running handler with ctx holding the
+// fields extracted from each message, along with its topic. paho handlers
+// receive no context, so ctx is typically the one of the subscribing client:
+//
+//	router.RegisterHandler("devices/+/telemetry", zaxmqtt.Handler(ctx, handle))
+func Handler(ctx context.Context, handler func(context.Context, *paho.Publish)) paho.MessageHandler {
+	return func(p *paho.Publish) {
+		msgCtx := zax.Append(ctx, []zap.Field{zap.String(TopicKey, p.Topic)})
+		handler(Extract(msgCtx, p), p)
+	}
+}
+
+// carrier is a [zax.Carrier] backed by user properties. Unlike HTTP headers,
+// user properties may repeat a key; Set replaces the first one.
+type carrier paho.UserProperties
+
+func (c *carrier) Get(key string) string {
+	return paho.UserProperties(*c).Get(key)
+}
+
+func (c *carrier) Set(key string, value string) {
+	for i := range *c {
+		if (*c)[i].Key == key {
+			(*c)[i].Value = value
+			return
+		}
+	}
+	*c = append(*c, paho.UserProperty{Key: key, Value: value})
+}
+
+func (c *carrier) Keys() []string {
+	keys := make([]string, 0, len(*c))
+	for _, property := range *c {
+		keys = append(keys, property.Key)
+	}
+	return keys
+}
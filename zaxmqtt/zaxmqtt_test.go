@@ -0,0 +1,45 @@
+//go:build !zax_disabled
+
+package zaxmqtt
+
+import (
+	"context"
+	"testing"
+
+	"github.com/eclipse/paho.golang/paho"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestInjectExtract(t *testing.T) {
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc"), zap.String("device", "d-1")})
+	p := &paho.Publish{Topic: "devices/d-1/telemetry"}
+	Inject(ctx, p)
+	Inject(zax.Append(ctx, []zap.Field{zap.String("trace_id", "def")}), p)
+	assert.Equal(t, paho.UserProperties{
+		{Key: "zax-device", Value: "d-1"},
+		{Key: "zax-trace_id", Value: "def"},
+	}, p.Properties.User, "injecting twice replaces values")
+
+	got := zax.GetAll(Extract(context.Background(), p))
+	assert.ElementsMatch(t, []zap.Field{zap.String("trace_id", "def"), zap.String("device", "d-1")}, got)
+}
+
+func TestHandler(t *testing.T) {
+	p := &paho.Publish{
+		Topic:      "devices/d-1/telemetry",
+		Properties: &paho.PublishProperties{User: paho.UserProperties{{Key: "zax-trace_id", Value: "abc"}}},
+	}
+
+	var got []zap.Field
+	Handler(context.Background(), func(ctx context.Context, _ *paho.Publish) {
+		got = zax.GetAll(ctx)
+	})(p)
+	assert.Equal(t, []zap.Field{zap.String("trace_id", "abc"), zap.String(TopicKey, "devices/d-1/telemetry")}, got)
+
+	Handler(context.Background(), func(ctx context.Context, _ *paho.Publish) {
+		got = zax.GetAll(ctx)
+	})(&paho.Publish{Topic: "status"})
+	assert.Equal(t, []zap.Field{zap.String(TopicKey, "status")}, got)
+}
@@ -0,0 +1,60 @@
+// Package zaxmux records the routes matched by gorilla/mux as zax fields.
+package zaxmux
+
+import (
+	"net/http"
+	"sort"
+
+	"github.com/gorilla/mux"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// RouteKey is the key of the field holding the template of the matched
+// route, such as /users/{id}.
+const RouteKey = "route"
+
+// VarPrefix prefixes the keys of the fields holding route variables.
+const VarPrefix = "route_"
+
+// Middleware returns mux middleware appending the template of the matched
+// route and its variables to the zax fields of the request context. Variables
+// named in denied, such as high-cardinality or sensitive ones, are left out:
+//
+//	router.Use(zaxmux.Middleware("token"))
+func Middleware(denied ...string) mux.MiddlewareFunc {
+	deny := make(map[string]bool, len(denied))
+	for _, name := range denied {
+		deny[name] = true
+	}
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if fields := routeFields(r, deny); len(fields) > 0 {
+				r = r.WithContext(zax.Append(r.Context(), fields))
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// routeFields returns the fields of the route matched by r.
+func routeFields(r *http.Request, deny map[string]bool) []zap.Field {
+	var fields []zap.Field
+	if route := mux.CurrentRoute(r); route != nil {
+		if template, err := route.GetPathTemplate(); err == nil {
+			fields = append(fields, zap.String(RouteKey, template))
+		}
+	}
+	vars := mux.Vars(r)
+	names := make([]string, 0, len(vars))
+	for name := range vars {
+		if !deny[name] {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		fields = append(fields, zap.String(VarPrefix+name, vars[name]))
+	}
+	return fields
+}
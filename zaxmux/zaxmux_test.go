@@ -0,0 +1,30 @@
+//go:build !zax_disabled
+
+package zaxmux
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestMiddleware(t *testing.T) {
+	var fields []zap.Field
+	router := mux.NewRouter()
+	router.Use(Middleware("token"))
+	router.HandleFunc("/users/{id}/orders/{order}/{token}", func(_ http.ResponseWriter, r *http.Request) {
+		fields = zax.GetAll(r.Context())
+	})
+
+	router.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/users/42/orders/7/secret", nil))
+	assert.Equal(t, []zap.Field{
+		zap.String(RouteKey, "/users/{id}/orders/{order}/{token}"),
+		zap.String(VarPrefix+"id", "42"),
+		zap.String(VarPrefix+"order", "7"),
+	}, fields)
+}
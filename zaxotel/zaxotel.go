@@ -0,0 +1,96 @@
+// Package zaxotel records the fields written with zax on the active
+// OpenTelemetry span, keeping traces and logs consistently enriched from a
+// single call.
+package zaxotel
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/yuseferi/zax/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// SpanAttributes returns an option making every Set and Append also set the
+// written fields as attributes of the span in the written context, if it is
+// recording:
+//
+//	zax.Configure(zaxotel.SpanAttributes())
+func SpanAttributes() zax.Option {
+	return hooks(func(ctx context.Context, event zax.WriteEvent) {
+		span := trace.SpanFromContext(ctx)
+		if span.IsRecording() {
+			span.SetAttributes(Attributes(event.Fields)...)
+		}
+	})
+}
+
+// SpanEvents returns an option making every Set and Append also add an event
+// named name, with the written fields as attributes, to the span in the
+// written context, if it is recording. Unlike attributes, events keep the
+// history of the values written to a key.
+func SpanEvents(name string) zax.Option {
+	return hooks(func(ctx context.Context, event zax.WriteEvent) {
+		span := trace.SpanFromContext(ctx)
+		if span.IsRecording() {
+			span.AddEvent(name, trace.WithAttributes(Attributes(event.Fields)...))
+		}
+	})
+}
+
+// hooks returns an option calling hook on every Set and Append.
+func hooks(hook zax.Hook) zax.Option {
+	return zax.Options(zax.WithSetHook(hook), zax.WithAppendHook(hook))
+}
+
+// Attributes converts fields to span attributes. Fields without a matching
+// attribute type are recorded as strings; skipped fields are left out.
+func Attributes(fields []zap.Field) []attribute.KeyValue {
+	attrs := make([]attribute.KeyValue, 0, len(fields))
+	for _, field := range fields {
+		if field.Type == zapcore.SkipType {
+			continue
+		}
+		attrs = append(attrs, Attribute(field))
+	}
+	return attrs
+}
+
+// Attribute converts field to a span attribute.
+func Attribute(field zap.Field) attribute.KeyValue {
+	key := field.Key
+	switch field.Type {
+	case zapcore.StringType:
+		return attribute.String(key, field.String)
+	case zapcore.BoolType:
+		return attribute.Bool(key, field.Integer == 1)
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type,
+		zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type:
+		return attribute.Int64(key, field.Integer)
+	case zapcore.Float64Type:
+		return attribute.Float64(key, math.Float64frombits(uint64(field.Integer)))
+	case zapcore.Float32Type:
+		return attribute.Float64(key, float64(math.Float32frombits(uint32(field.Integer))))
+	case zapcore.DurationType:
+		return attribute.String(key, time.Duration(field.Integer).String())
+	case zapcore.StringerType:
+		return attribute.String(key, fmt.Sprint(field.Interface))
+	case zapcore.ErrorType:
+		if err, ok := field.Interface.(error); ok {
+			return attribute.String(key, err.Error())
+		}
+	}
+	return attribute.String(key, encodedString(field))
+}
+
+// encodedString renders the value of field as zap encodes it.
+func encodedString(field zap.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	return fmt.Sprint(enc.Fields[field.Key])
+}
@@ -0,0 +1,63 @@
+//go:build !zax_disabled
+
+package zaxotel
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.uber.org/zap"
+)
+
+func TestSpanAttributes(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+	store := zax.NewStore(SpanAttributes(), SpanEvents("zax"))
+
+	ctx, span := tracer.Start(context.Background(), "request")
+	ctx = store.Set(ctx, []zap.Field{zap.String("user_id", "alice")})
+	store.Append(ctx, []zap.Field{zap.Int("attempt", 2)})
+	span.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 1)
+	assert.ElementsMatch(t, []attribute.KeyValue{
+		attribute.String("user_id", "alice"),
+		attribute.Int64("attempt", 2),
+	}, spans[0].Attributes())
+	require.Len(t, spans[0].Events(), 2)
+	assert.Equal(t, "zax", spans[0].Events()[0].Name)
+	assert.Equal(t, []attribute.KeyValue{attribute.String("user_id", "alice")}, spans[0].Events()[0].Attributes)
+
+	// Without a recording span, writes are unaffected.
+	assert.Len(t, store.GetAll(store.Set(context.Background(), []zap.Field{zap.String("k", "v")})), 1)
+}
+
+func TestAttributes(t *testing.T) {
+	assert.Equal(t, []attribute.KeyValue{
+		attribute.String("string", "value"),
+		attribute.Bool("bool", true),
+		attribute.Int64("int", -3),
+		attribute.Float64("float", 1.5),
+		attribute.String("duration", "2s"),
+		attribute.String("error", "failed"),
+		attribute.String("strings", "[a b]"),
+	}, Attributes([]zap.Field{
+		zap.String("string", "value"),
+		zap.Bool("bool", true),
+		zap.Int8("int", -3),
+		zap.Float64("float", 1.5),
+		zap.Duration("duration", 2*time.Second),
+		zap.NamedError("error", errors.New("failed")),
+		zap.Skip(),
+		zap.Strings("strings", []string{"a", "b"}),
+	}))
+}
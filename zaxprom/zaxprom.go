@@ -0,0 +1,44 @@
+// Package zaxprom exports zax metrics to Prometheus.
+package zaxprom
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// KeyLabel is the label holding the looked up key.
+const KeyLabel = "key"
+
+// AbsentCounter is a [zax.AbsentCounter] backed by a Prometheus counter
+// labeled by [KeyLabel]. Register it to expose it:
+//
+//	counter := zaxprom.NewAbsentCounter(prometheus.CounterOpts{
+//		Name: "zax_absent_fields_total",
+//		Help: "Lookups of context fields that were not set.",
+//	})
+//	prometheus.MustRegister(counter)
+//	zax.Configure(zax.WithAbsentCounter(counter))
+//
+// [zax.AbsentCounter]: https://pkg.go.dev/github.com/yuseferi/zax/v2#AbsentCounter
+type AbsentCounter struct {
+	vec *prometheus.CounterVec
+}
+
+// NewAbsentCounter returns a counter described by opts.
+func NewAbsentCounter(opts prometheus.CounterOpts) *AbsentCounter {
+	return &AbsentCounter{vec: prometheus.NewCounterVec(opts, []string{KeyLabel})}
+}
+
+// Inc increments the counter of key.
+func (a *AbsentCounter) Inc(key string) {
+	a.vec.WithLabelValues(key).Inc()
+}
+
+// Describe implements [prometheus.Collector].
+func (a *AbsentCounter) Describe(ch chan<- *prometheus.Desc) {
+	a.vec.Describe(ch)
+}
+
+// Collect implements [prometheus.Collector].
+func (a *AbsentCounter) Collect(ch chan<- prometheus.Metric) {
+	a.vec.Collect(ch)
+}
@@ -0,0 +1,34 @@
+//go:build !zax_disabled
+
+package zaxprom
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestAbsentCounter(t *testing.T) {
+	counter := NewAbsentCounter(prometheus.CounterOpts{Name: "zax_absent_fields_total", Help: "Absent fields."})
+	registry := prometheus.NewRegistry()
+	require.NoError(t, registry.Register(counter))
+
+	store := zax.NewStore(zax.WithAbsentCounter(counter))
+	ctx := store.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc")})
+	store.GetFields(ctx, "trace_id", "span_id")
+	store.GetField(ctx, "span_id")
+
+	expected := `
+# HELP zax_absent_fields_total Absent fields.
+# TYPE zax_absent_fields_total counter
+zax_absent_fields_total{key="span_id"} 2
+`
+	assert.NoError(t, testutil.GatherAndCompare(registry, strings.NewReader(expected)))
+}
@@ -0,0 +1,241 @@
+// Package zaxproto encodes zax fields as the protobuf messages described in
+// fields.proto, so they can travel in a single binary gRPC metadata entry
+// instead of one string header per field.
+package zaxproto
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// MetadataKey is the gRPC metadata key conventionally used to carry the
+// encoded fields. The -bin suffix makes gRPC transmit the value as binary.
+const MetadataKey = "zax-fields-bin"
+
+// Field numbers, as declared in fields.proto.
+const (
+	fieldsField protowire.Number = 1
+
+	keyField           protowire.Number = 1
+	stringValueField   protowire.Number = 2
+	boolValueField     protowire.Number = 3
+	intValueField      protowire.Number = 4
+	uintValueField     protowire.Number = 5
+	doubleValueField   protowire.Number = 6
+	durationNanosField protowire.Number = 7
+	timeUnixNanosField protowire.Number = 8
+	bytesValueField    protowire.Number = 9
+	errorValueField    protowire.Number = 10
+	jsonValueField     protowire.Number = 11
+	byteStringField    protowire.Number = 12
+)
+
+// Marshal encodes the fields stored in ctx as a Fields message. Like
+// [zax.MarshalJSON], it leaves the defaults out.
+func Marshal(ctx context.Context) ([]byte, error) {
+	var b []byte
+	for _, field := range zax.StoredView(ctx).Unsafe() {
+		if field.Type == zapcore.SkipType {
+			continue
+		}
+		encoded, err := appendField(nil, field)
+		if err != nil {
+			return nil, err
+		}
+		b = protowire.AppendTag(b, fieldsField, protowire.BytesType)
+		b = protowire.AppendBytes(b, encoded)
+	}
+	return b, nil
+}
+
+// Unmarshal appends the fields of the Fields message b to ctx.
+func Unmarshal(ctx context.Context, b []byte) (context.Context, error) {
+	var fields []zap.Field
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return ctx, protowire.ParseError(n)
+		}
+		b = b[n:]
+		if num != fieldsField || typ != protowire.BytesType {
+			n = protowire.ConsumeFieldValue(num, typ, b)
+			if n < 0 {
+				return ctx, protowire.ParseError(n)
+			}
+			b = b[n:]
+			continue
+		}
+		encoded, n := protowire.ConsumeBytes(b)
+		if n < 0 {
+			return ctx, protowire.ParseError(n)
+		}
+		b = b[n:]
+		field, err := consumeField(encoded)
+		if err != nil {
+			return ctx, err
+		}
+		fields = append(fields, field)
+	}
+	if len(fields) == 0 {
+		return ctx, nil
+	}
+	return zax.Append(ctx, fields), nil
+}
+
+// appendField appends field encoded as a Field message to b.
+func appendField(b []byte, field zap.Field) ([]byte, error) {
+	b = protowire.AppendTag(b, keyField, protowire.BytesType)
+	b = protowire.AppendString(b, field.Key)
+	switch field.Type {
+	case zapcore.StringType:
+		b = appendString(b, stringValueField, field.String)
+	case zapcore.StringerType:
+		b = appendString(b, stringValueField, field.Interface.(fmt.Stringer).String())
+	case zapcore.BinaryType:
+		b = appendString(b, bytesValueField, string(field.Interface.([]byte)))
+	case zapcore.ByteStringType:
+		b = appendString(b, byteStringField, string(field.Interface.([]byte)))
+	case zapcore.ErrorType:
+		b = appendString(b, errorValueField, field.Interface.(error).Error())
+	case zapcore.BoolType:
+		b = protowire.AppendTag(b, boolValueField, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(field.Integer == 1))
+	case zapcore.Int64Type, zapcore.Int32Type, zapcore.Int16Type, zapcore.Int8Type:
+		b = protowire.AppendTag(b, intValueField, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeZigZag(field.Integer))
+	case zapcore.Uint64Type, zapcore.Uint32Type, zapcore.Uint16Type, zapcore.Uint8Type, zapcore.UintptrType:
+		b = protowire.AppendTag(b, uintValueField, protowire.VarintType)
+		b = protowire.AppendVarint(b, uint64(field.Integer))
+	default:
+		return appendOther(b, field)
+	}
+	return b, nil
+}
+
+// appendOther encodes the value of the field types without a varint or
+// string representation of their own.
+func appendOther(b []byte, field zap.Field) ([]byte, error) {
+	switch field.Type {
+	case zapcore.Float64Type:
+		b = protowire.AppendTag(b, doubleValueField, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, uint64(field.Integer)), nil
+	case zapcore.Float32Type:
+		f := float64(math.Float32frombits(uint32(field.Integer)))
+		b = protowire.AppendTag(b, doubleValueField, protowire.Fixed64Type)
+		return protowire.AppendFixed64(b, math.Float64bits(f)), nil
+	case zapcore.DurationType:
+		b = protowire.AppendTag(b, durationNanosField, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(field.Integer)), nil
+	case zapcore.TimeType:
+		b = protowire.AppendTag(b, timeUnixNanosField, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(field.Integer)), nil
+	case zapcore.TimeFullType:
+		b = protowire.AppendTag(b, timeUnixNanosField, protowire.VarintType)
+		return protowire.AppendVarint(b, uint64(field.Interface.(time.Time).UnixNano())), nil
+	}
+	encoded, err := json.Marshal(zax.EncodedValue(field))
+	if err != nil {
+		return nil, fmt.Errorf("zaxproto: marshal field %q: %w", field.Key, err)
+	}
+	return appendString(b, jsonValueField, string(encoded)), nil
+}
+
+func appendString(b []byte, num protowire.Number, s string) []byte {
+	b = protowire.AppendTag(b, num, protowire.BytesType)
+	return protowire.AppendString(b, s)
+}
+
+// consumeField decodes a Field message.
+func consumeField(b []byte) (zap.Field, error) {
+	var (
+		key   string
+		field zap.Field
+	)
+	for len(b) > 0 {
+		num, typ, n := protowire.ConsumeTag(b)
+		if n < 0 {
+			return zap.Field{}, protowire.ParseError(n)
+		}
+		b = b[n:]
+		var err error
+		if num == keyField && typ == protowire.BytesType {
+			key, n = protowire.ConsumeString(b)
+		} else {
+			field, n, err = consumeValue(num, typ, b)
+		}
+		if n < 0 {
+			return zap.Field{}, protowire.ParseError(n)
+		}
+		if err != nil {
+			return zap.Field{}, err
+		}
+		b = b[n:]
+	}
+	field.Key = key
+	return field, nil
+}
+
+// consumeValue decodes one case of the value oneof into a field without key.
+func consumeValue(num protowire.Number, typ protowire.Type, b []byte) (zap.Field, int, error) {
+	switch typ {
+	case protowire.VarintType:
+		v, n := protowire.ConsumeVarint(b)
+		return varintField(num, v), n, nil
+	case protowire.Fixed64Type:
+		v, n := protowire.ConsumeFixed64(b)
+		if num != doubleValueField {
+			return zap.Skip(), n, nil
+		}
+		return zap.Float64("", math.Float64frombits(v)), n, nil
+	case protowire.BytesType:
+		v, n := protowire.ConsumeBytes(b)
+		field, err := bytesField(num, v)
+		return field, n, err
+	}
+	return zap.Skip(), protowire.ConsumeFieldValue(num, typ, b), nil
+}
+
+func varintField(num protowire.Number, v uint64) zap.Field {
+	switch num {
+	case boolValueField:
+		return zap.Bool("", protowire.DecodeBool(v))
+	case intValueField:
+		return zap.Int64("", protowire.DecodeZigZag(v))
+	case uintValueField:
+		return zap.Uint64("", v)
+	case durationNanosField:
+		return zap.Duration("", time.Duration(v))
+	case timeUnixNanosField:
+		return zap.Time("", time.Unix(0, int64(v)))
+	}
+	return zap.Skip()
+}
+
+func bytesField(num protowire.Number, v []byte) (zap.Field, error) {
+	switch num {
+	case stringValueField:
+		return zap.String("", string(v)), nil
+	case bytesValueField:
+		return zap.Binary("", append([]byte(nil), v...)), nil
+	case byteStringField:
+		return zap.ByteString("", append([]byte(nil), v...)), nil
+	case errorValueField:
+		return zap.Error(errors.New(string(v))), nil
+	case jsonValueField:
+		var value any
+		if err := json.Unmarshal(v, &value); err != nil {
+			return zap.Field{}, fmt.Errorf("zaxproto: unmarshal json value: %w", err)
+		}
+		return zap.Any("", value), nil
+	}
+	return zap.Skip(), nil
+}
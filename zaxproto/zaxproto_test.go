@@ -0,0 +1,91 @@
+//go:build !zax_disabled
+
+package zaxproto
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestRoundTrip(t *testing.T) {
+	now := time.Now()
+	ctx := zax.Set(context.Background(), []zap.Field{
+		zap.String("trace_id", "trace"),
+		zap.Bool("sampled", true),
+		zap.Int("attempt", -3),
+		zap.Uint32("shard", 7),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("budget", time.Second),
+		zap.Time("start", now),
+		zap.Binary("payload", []byte{1, 2, 3}),
+		zap.ByteString("body", []byte("hi")),
+		zap.NamedError("cause", errors.New("boom")),
+		zap.Strings("tags", []string{"a", "b"}),
+		zap.Inline(zapcore.ObjectMarshalerFunc(func(enc zapcore.ObjectEncoder) error {
+			enc.AddString("user", "alice")
+			return nil
+		})),
+	})
+
+	b, err := Marshal(ctx)
+	require.NoError(t, err)
+
+	restored, err := Unmarshal(context.Background(), b)
+	require.NoError(t, err)
+
+	expected := []zap.Field{
+		zap.String("trace_id", "trace"),
+		zap.Bool("sampled", true),
+		zap.Int64("attempt", -3),
+		zap.Uint64("shard", 7),
+		zap.Float64("ratio", 0.5),
+		zap.Duration("budget", time.Second),
+		zap.Time("start", time.Unix(0, now.UnixNano())),
+		zap.Binary("payload", []byte{1, 2, 3}),
+		zap.ByteString("body", []byte("hi")),
+	}
+	got := zax.GetAll(restored)
+	require.Len(t, got, 12)
+	assert.Equal(t, expected, got[:9])
+	assert.Equal(t, "cause", got[9].Key)
+	assert.EqualError(t, got[9].Interface.(error), "boom")
+	assert.Equal(t, zap.Any("tags", []any{"a", "b"}), got[10])
+	assert.Equal(t, zap.Any("", map[string]any{"user": "alice"}), got[11], "inline objects keep their keys")
+}
+
+func TestUnmarshalSkipsUnknownFields(t *testing.T) {
+	b := protowire.AppendTag(nil, 15, protowire.VarintType)
+	b = protowire.AppendVarint(b, 1)
+
+	ctx := context.Background()
+	restored, err := Unmarshal(ctx, b)
+	require.NoError(t, err)
+	assert.Equal(t, ctx, restored)
+}
+
+func TestUnmarshalMalformed(t *testing.T) {
+	_, err := Unmarshal(context.Background(), []byte{0x0a, 0x05})
+	assert.Error(t, err)
+}
+
+func TestMarshalLeavesDefaultsOut(t *testing.T) {
+	zax.SetDefaults(zap.String("service", "api"))
+	t.Cleanup(func() { zax.SetDefaults() })
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "trace")})
+
+	b, err := Marshal(ctx)
+	require.NoError(t, err)
+	restored, err := Unmarshal(context.Background(), b)
+	require.NoError(t, err)
+
+	assert.Equal(t, []zap.Field{zap.String("trace_id", "trace")}, zax.StoredView(restored).Fields())
+}
@@ -0,0 +1,40 @@
+// Package zaxpubsub carries zax fields through Google Cloud Pub/Sub message
+// attributes.
+package zaxpubsub
+
+import (
+	"context"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// MessageIDKey is the key of the field holding the ID of received messages.
+const MessageIDKey = "pubsub_message_id"
+
+// Inject writes the fields stored in ctx to the attributes of msg, see
+// [zax.Inject].
+func Inject(ctx context.Context, msg *pubsub.Message) {
+	if msg.Attributes == nil {
+		msg.Attributes = make(map[string]string)
+	}
+	zax.Inject(ctx, zax.MapCarrier(msg.Attributes))
+}
+
+// Extract appends the fields written by [Inject] to the attributes of msg to
+// ctx, see [zax.Extract].
+func Extract(ctx context.Context, msg *pubsub.Message) context.Context {
+	return zax.Extract(ctx, zax.MapCarrier(msg.Attributes))
+}
+
+// Receive returns handler receiving a context holding the fields extracted
+// from each message, along with its ID:
+//
+//	err := sub.Receive(ctx, zaxpubsub.Receive(handle))
+func Receive(handler func(context.Context, *pubsub.Message)) func(context.Context, *pubsub.Message) {
+	return func(ctx context.Context, msg *pubsub.Message) {
+		ctx = Extract(ctx, msg)
+		handler(zax.Append(ctx, []zap.Field{zap.String(MessageIDKey, msg.ID)}), msg)
+	}
+}
@@ -0,0 +1,34 @@
+//go:build !zax_disabled
+
+package zaxpubsub
+
+import (
+	"context"
+	"testing"
+
+	"cloud.google.com/go/pubsub"
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestInjectExtract(t *testing.T) {
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc"), zap.Int("attempt", 2)})
+	msg := &pubsub.Message{Data: []byte("payload")}
+	Inject(ctx, msg)
+	assert.Equal(t, map[string]string{"zax-trace_id": "abc", "zax-attempt": "2"}, msg.Attributes)
+
+	got := zax.GetAll(Extract(context.Background(), msg))
+	assert.ElementsMatch(t, []zap.Field{zap.String("trace_id", "abc"), zap.String("attempt", "2")}, got)
+}
+
+func TestReceive(t *testing.T) {
+	msg := &pubsub.Message{ID: "m-1", Attributes: map[string]string{"zax-trace_id": "abc", "origin": "billing"}}
+
+	var got []zap.Field
+	Receive(func(ctx context.Context, _ *pubsub.Message) {
+		got = zax.GetAll(ctx)
+	})(context.Background(), msg)
+
+	assert.Equal(t, []zap.Field{zap.String(MessageIDKey, "m-1"), zap.String("trace_id", "abc")}, got)
+}
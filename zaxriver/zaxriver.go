@@ -0,0 +1,105 @@
+// Package zaxriver carries zax fields through River jobs: fields of the
+// inserting context are stored in the job metadata, and appended to the
+// context of the worker picking the job up, so that Postgres-queued jobs log
+// with the correlation IDs of the request that enqueued them.
+package zaxriver
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/riverqueue/river"
+	"github.com/riverqueue/river/rivertype"
+	"github.com/yuseferi/zax/v2"
+)
+
+// Middleware stores the fields of the inserting context in the metadata of
+// inserted jobs, and appends them to the context of the worker working them.
+// Register it as both kinds of middleware:
+//
+//	middleware := &zaxriver.Middleware{}
+//	client, err := river.NewClient(driver, &river.Config{
+//		JobInsertMiddleware: []rivertype.JobInsertMiddleware{middleware},
+//		WorkerMiddleware:    []rivertype.WorkerMiddleware{middleware},
+//	})
+type Middleware struct {
+	river.JobInsertMiddlewareDefaults
+	river.WorkerMiddlewareDefaults
+}
+
+var (
+	_ rivertype.JobInsertMiddleware = (*Middleware)(nil)
+	_ rivertype.WorkerMiddleware    = (*Middleware)(nil)
+)
+
+// InsertMany stores the fields of ctx in the metadata of every inserted job.
+func (m *Middleware) InsertMany(
+	ctx context.Context,
+	manyParams []*rivertype.JobInsertParams,
+	doInner func(context.Context) ([]*rivertype.JobInsertResult, error),
+) ([]*rivertype.JobInsertResult, error) {
+	for _, params := range manyParams {
+		metadata, err := inject(ctx, params.Metadata)
+		if err != nil {
+			return nil, err
+		}
+		params.Metadata = metadata
+	}
+	return doInner(ctx)
+}
+
+// Work appends the fields stored in the metadata of job to ctx.
+func (m *Middleware) Work(ctx context.Context, job *rivertype.JobRow, doInner func(context.Context) error) error {
+	ctx, err := Extract(ctx, job)
+	if err != nil {
+		return err
+	}
+	return doInner(ctx)
+}
+
+// InsertOpts stores the fields of ctx in the metadata of opts, for clients
+// inserting jobs without [Middleware]. A nil opts is allocated; opts is
+// modified in place otherwise.
+//
+//	opts, err := zaxriver.InsertOpts(ctx, nil)
+//	_, err = client.Insert(ctx, args, opts)
+func InsertOpts(ctx context.Context, opts *river.InsertOpts) (*river.InsertOpts, error) {
+	if opts == nil {
+		opts = &river.InsertOpts{}
+	}
+	metadata, err := inject(ctx, opts.Metadata)
+	if err != nil {
+		return opts, err
+	}
+	opts.Metadata = metadata
+	return opts, nil
+}
+
+// Extract appends the fields stored in the metadata of job to ctx, for
+// workers running without [Middleware].
+func Extract(ctx context.Context, job *rivertype.JobRow) (context.Context, error) {
+	if len(job.Metadata) == 0 {
+		return ctx, nil
+	}
+	var metadata map[string]any
+	if err := json.Unmarshal(job.Metadata, &metadata); err != nil {
+		return ctx, fmt.Errorf("zaxriver: job metadata: %w", err)
+	}
+	return zax.Dequeue(ctx, metadata)
+}
+
+// inject adds the fields of ctx to the JSON object data.
+func inject(ctx context.Context, data []byte) ([]byte, error) {
+	var metadata map[string]any
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &metadata); err != nil {
+			return data, fmt.Errorf("zaxriver: job metadata: %w", err)
+		}
+	}
+	metadata, err := zax.Enqueue(ctx, metadata)
+	if err != nil || metadata == nil {
+		return data, err
+	}
+	return json.Marshal(metadata)
+}
@@ -0,0 +1,58 @@
+//go:build !zax_disabled
+
+package zaxriver
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/riverqueue/river/rivertype"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestMiddleware(t *testing.T) {
+	m := &Middleware{}
+	ctx := zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc")})
+	params := []*rivertype.JobInsertParams{{Kind: "email", Metadata: []byte(`{"owner":"billing"}`)}}
+
+	_, err := m.InsertMany(ctx, params, func(context.Context) ([]*rivertype.JobInsertResult, error) {
+		return nil, nil
+	})
+	require.NoError(t, err)
+	var metadata map[string]any
+	require.NoError(t, json.Unmarshal(params[0].Metadata, &metadata))
+	assert.Equal(t, "billing", metadata["owner"], "existing metadata is kept")
+	assert.Contains(t, metadata, zax.MetadataKey)
+
+	var got []zap.Field
+	job := &rivertype.JobRow{Kind: "email", Metadata: params[0].Metadata}
+	err = m.Work(context.Background(), job, func(ctx context.Context) error {
+		got = zax.GetAll(ctx)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.String("trace_id", "abc")}, got)
+}
+
+func TestInsertOpts(t *testing.T) {
+	opts, err := InsertOpts(context.Background(), nil)
+	require.NoError(t, err)
+	assert.Nil(t, opts.Metadata, "contexts without fields leave metadata alone")
+
+	ctx := zax.Set(context.Background(), []zap.Field{zap.Int("attempt", 2)})
+	opts, err = InsertOpts(ctx, opts)
+	require.NoError(t, err)
+
+	jobCtx, err := Extract(context.Background(), &rivertype.JobRow{Metadata: opts.Metadata})
+	require.NoError(t, err)
+	assert.Equal(t, []zap.Field{zap.Int64("attempt", 2)}, zax.GetAll(jobCtx))
+}
+
+func TestExtractInvalid(t *testing.T) {
+	_, err := Extract(context.Background(), &rivertype.JobRow{Metadata: []byte("[")})
+	assert.Error(t, err)
+}
@@ -0,0 +1,142 @@
+// Package zaxsentry mirrors the fields stored with zax into Sentry scopes, so
+// that the exceptions reported to Sentry carry the same correlation data as
+// the logs.
+package zaxsentry
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+	"unicode/utf8"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// ContextKey is the name of the Sentry context holding the fields.
+const ContextKey = "zax"
+
+// MaxTagLength is the maximum length, in bytes, of the tag values Sentry
+// accepts; longer values are truncated.
+const MaxTagLength = 200
+
+// Mirror mirrors the fields stored in contexts into Sentry scopes: every
+// field is set in the [ContextKey] context, and the fields with any of Tags
+// are also set as tags, which Sentry indexes for search.
+type Mirror struct {
+	// Store holds the fields; nil means the default store of zax.
+	Store *zax.Store
+	// Tags are the keys of the fields set as tags.
+	Tags []string
+	// Redacted are the keys of the fields whose values are replaced by
+	// [zax.Redacted], on top of the ones redacted when stored, as configured
+	// with [zax.WithRedactedKeys].
+	Redacted []string
+}
+
+// Apply sets the fields stored in ctx on scope.
+func (m Mirror) Apply(ctx context.Context, scope *sentry.Scope) {
+	fields := m.view(ctx)
+	if fields.Len() == 0 {
+		return
+	}
+	values := make(sentry.Context, fields.Len())
+	fields.Range(func(field zap.Field) bool {
+		if field.Type == zapcore.SkipType {
+			return true
+		}
+		if _, ok := values[field.Key]; ok {
+			// Fields are ordered newest first.
+			return true
+		}
+		value := m.value(field)
+		values[field.Key] = value
+		if contains(m.Tags, field.Key) {
+			scope.SetTag(field.Key, tagValue(value))
+		}
+		return true
+	})
+	scope.SetContext(ContextKey, values)
+}
+
+// Hub returns the Sentry hub of ctx, or a clone of the current hub if ctx has
+// none, with the fields stored in ctx set on its scope.
+func (m Mirror) Hub(ctx context.Context) *sentry.Hub {
+	hub := sentry.GetHubFromContext(ctx)
+	if hub == nil {
+		hub = sentry.CurrentHub().Clone()
+	}
+	hub.ConfigureScope(func(scope *sentry.Scope) {
+		m.Apply(ctx, scope)
+	})
+	return hub
+}
+
+// Middleware returns a middleware setting the fields stored in the context of
+// requests on the scope of their Sentry hub, which it adds to the context if
+// missing. It must run after the middlewares storing the fields, and after
+// the handler of sentryhttp, if any, to configure the hub it creates.
+func (m Mirror) Middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := r.Context()
+		hub := m.Hub(ctx)
+		if sentry.GetHubFromContext(ctx) == nil {
+			r = r.WithContext(sentry.SetHubOnContext(ctx, hub))
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+func (m Mirror) view(ctx context.Context) zax.FieldView {
+	if m.Store != nil {
+		return m.Store.View(ctx)
+	}
+	return zax.View(ctx)
+}
+
+// value returns the value of field as Sentry serializes it.
+func (m Mirror) value(field zap.Field) any {
+	if contains(m.Redacted, field.Key) {
+		return zax.Redacted
+	}
+	switch value := zax.Value(field).(type) {
+	case time.Time:
+		return value
+	case error:
+		return value.Error()
+	case fmt.Stringer:
+		return value.String()
+	case []byte:
+		return string(value)
+	default:
+		return value
+	}
+}
+
+// tagValue returns value as the text of a tag.
+func tagValue(value any) string {
+	text, ok := value.(string)
+	if !ok {
+		text = fmt.Sprint(value)
+	}
+	if len(text) <= MaxTagLength {
+		return text
+	}
+	n := MaxTagLength
+	for n > 0 && !utf8.RuneStart(text[n]) {
+		n--
+	}
+	return text[:n]
+}
+
+func contains(keys []string, key string) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
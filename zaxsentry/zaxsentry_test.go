@@ -0,0 +1,97 @@
+//go:build !zax_disabled
+
+package zaxsentry
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getsentry/sentry-go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// transport records the events sent to Sentry.
+type transport struct {
+	events []*sentry.Event
+}
+
+func (t *transport) Flush(time.Duration) bool       { return true }
+func (t *transport) Configure(sentry.ClientOptions) {}
+func (t *transport) SendEvent(event *sentry.Event)  { t.events = append(t.events, event) }
+func (t *transport) Close()                         {}
+
+func newHub(t *testing.T) (*sentry.Hub, *transport) {
+	t.Helper()
+	recorded := &transport{}
+	client, err := sentry.NewClient(sentry.ClientOptions{Transport: recorded})
+	require.NoError(t, err)
+	return sentry.NewHub(client, sentry.NewScope()), recorded
+}
+
+func TestMirrorHub(t *testing.T) {
+	hub, recorded := newHub(t)
+	ctx := sentry.SetHubOnContext(context.Background(), hub)
+	ctx = zax.Set(ctx, []zap.Field{
+		zap.String("trace_id", "trace"),
+		zap.Int("attempt", 2),
+		zap.String("email", "user@example.com"),
+		zap.Error(errors.New("boom")),
+		zap.String("long", strings.Repeat("x", MaxTagLength+1)),
+	})
+	ctx = zax.Append(ctx, []zap.Field{zap.Int("attempt", 3)})
+	mirror := Mirror{Tags: []string{"trace_id", "attempt", "long"}, Redacted: []string{"email"}}
+
+	assert.Same(t, hub, mirror.Hub(ctx))
+	hub.CaptureMessage("failed")
+
+	require.Len(t, recorded.events, 1)
+	event := recorded.events[0]
+	assert.Equal(t, map[string]string{
+		"trace_id": "trace",
+		"attempt":  "3",
+		"long":     strings.Repeat("x", MaxTagLength),
+	}, event.Tags)
+	assert.Equal(t, map[string]interface{}{
+		"trace_id": "trace",
+		"attempt":  int64(3),
+		"email":    zax.Redacted,
+		"error":    "boom",
+		"long":     strings.Repeat("x", MaxTagLength+1),
+	}, event.Contexts[ContextKey])
+}
+
+func TestMirrorStore(t *testing.T) {
+	hub, recorded := newHub(t)
+	store := zax.NewStore()
+	ctx := sentry.SetHubOnContext(context.Background(), hub)
+	ctx = zax.Set(store.Set(ctx, []zap.Field{zap.String("tenant", "acme")}), []zap.Field{zap.String("trace_id", "trace")})
+
+	Mirror{Store: store}.Hub(ctx).CaptureMessage("failed")
+
+	require.Len(t, recorded.events, 1)
+	assert.Equal(t, map[string]interface{}{"tenant": "acme"}, recorded.events[0].Contexts[ContextKey])
+}
+
+func TestMiddleware(t *testing.T) {
+	var hub *sentry.Hub
+	handler := Mirror{Tags: []string{"trace_id"}}.Middleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hub = sentry.GetHubFromContext(r.Context())
+	}))
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r = r.WithContext(zax.Set(r.Context(), []zap.Field{zap.String("trace_id", "trace")}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), r)
+
+	require.NotNil(t, hub)
+	assert.NotSame(t, sentry.CurrentHub(), hub, "the current hub is cloned")
+	event := hub.Scope().ApplyToEvent(&sentry.Event{}, nil, nil)
+	assert.Equal(t, "trace", event.Tags["trace_id"])
+}
@@ -0,0 +1,70 @@
+package zaxtest
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap/zapcore"
+)
+
+// Clock is a manual [zapcore.Clock], whose time only moves when advanced,
+// making time-based behavior deterministic in tests. Pass it to
+// zax.WithClock, and to [zap.WithClock] for the time of entries.
+//
+// Its tickers fire when the clock is advanced past their next tick. Like
+// real tickers, they drop ticks while their channel is full; stopping them
+// has no effect.
+type Clock struct {
+	mu      sync.Mutex
+	now     time.Time
+	tickers []*manualTicker
+}
+
+type manualTicker struct {
+	period time.Duration
+	next   time.Time
+	c      chan time.Time
+}
+
+// NewClock returns a clock set to start.
+func NewClock(start time.Time) *Clock {
+	return &Clock{now: start}
+}
+
+// Now returns the time of the clock.
+func (c *Clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+// NewTicker returns a ticker firing every period of the clock's time.
+func (c *Clock) NewTicker(period time.Duration) *time.Ticker {
+	if period <= 0 {
+		panic("zaxtest: non-positive interval for NewTicker")
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	t := &manualTicker{period: period, next: c.now.Add(period), c: make(chan time.Time, 1)}
+	c.tickers = append(c.tickers, t)
+	return &time.Ticker{C: t.c}
+}
+
+// Add advances the clock by d, firing the tickers due meanwhile once for
+// each of their ticks.
+func (c *Clock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+	for _, t := range c.tickers {
+		for !t.next.After(c.now) {
+			select {
+			case t.c <- t.next:
+			default:
+			}
+			t.next = t.next.Add(t.period)
+		}
+	}
+}
+
+var _ zapcore.Clock = (*Clock)(nil)
@@ -0,0 +1,25 @@
+package zaxtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestClock(t *testing.T) {
+	start := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	clock := NewClock(start)
+	ticker := clock.NewTicker(time.Second)
+
+	clock.Add(500 * time.Millisecond)
+	assert.Equal(t, start.Add(500*time.Millisecond), clock.Now())
+	assert.Empty(t, ticker.C)
+
+	clock.Add(3 * time.Second)
+	assert.Equal(t, start.Add(time.Second), <-ticker.C, "ticks beyond the capacity of the channel are dropped")
+	assert.Empty(t, ticker.C)
+
+	clock.Add(time.Second)
+	assert.Equal(t, start.Add(4*time.Second), <-ticker.C)
+}
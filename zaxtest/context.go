@@ -0,0 +1,26 @@
+package zaxtest
+
+import (
+	"context"
+	"testing"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Context returns a context holding fields, as stored by zax.Set, for unit
+// tests of handlers that read them. It is canceled when the test ends.
+func Context(t testing.TB, fields ...zap.Field) context.Context {
+	t.Helper()
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	return zax.Set(ctx, fields)
+}
+
+// ContextWithLogger is like [Context], but also returns a recording logger,
+// whose entries carry fields when logged through zax.Logger with the
+// returned context.
+func ContextWithLogger(t testing.TB, fields ...zap.Field) (context.Context, *Logger) {
+	t.Helper()
+	return Context(t, fields...), NewLogger(t)
+}
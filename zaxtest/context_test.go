@@ -0,0 +1,31 @@
+//go:build !zax_disabled
+
+package zaxtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestContext(t *testing.T) {
+	var ctx interface{ Err() error }
+	t.Run("test", func(t *testing.T) {
+		c := Context(t, zap.String("trace_id", "trace"), zap.Int("attempt", 1))
+		ctx = c
+
+		assert.Equal(t, []zap.Field{zap.String("trace_id", "trace"), zap.Int("attempt", 1)}, zax.GetAll(c))
+		assert.NoError(t, c.Err())
+	})
+	assert.Error(t, ctx.Err(), "the context is canceled when the test ends")
+}
+
+func TestContextWithLogger(t *testing.T) {
+	ctx, logs := ContextWithLogger(t, zap.String("trace_id", "trace"))
+
+	zax.Logger(ctx, logs.GetZapLogger()).Info("handled")
+
+	assert.True(t, logs.AssertLogEntryExist(t, "trace_id", "trace"))
+}
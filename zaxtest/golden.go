@@ -0,0 +1,136 @@
+package zaxtest
+
+import (
+	"bytes"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// UpdateGoldenEnv is the environment variable that, when set to a non-empty
+// value, makes [Logger.AssertGolden] rewrite golden files instead of
+// comparing against them.
+const UpdateGoldenEnv = "ZAXTEST_UPDATE_GOLDEN"
+
+// redacted replaces volatile values in golden output.
+const redacted = "<redacted>"
+
+// GoldenOption adjusts how entries are normalized for golden comparison.
+type GoldenOption func(*goldenConfig)
+
+type goldenConfig struct {
+	keys     map[string]bool
+	patterns []*regexp.Regexp
+	times    bool
+}
+
+// RedactKeys replaces the values of fields with any of keys.
+func RedactKeys(keys ...string) GoldenOption {
+	return func(c *goldenConfig) {
+		for _, key := range keys {
+			c.keys[key] = true
+		}
+	}
+}
+
+// RedactPattern replaces the parts of string values and messages matching
+// pattern, e.g. generated IDs.
+func RedactPattern(pattern *regexp.Regexp) GoldenOption {
+	return func(c *goldenConfig) {
+		c.patterns = append(c.patterns, pattern)
+	}
+}
+
+// RedactTimes replaces the values of all time fields.
+func RedactTimes() GoldenOption {
+	return func(c *goldenConfig) {
+		c.times = true
+	}
+}
+
+// AssertGolden asserts that the recorded entries, rendered as normalized JSON
+// lines, equal the content of the golden file at path. Entry timestamps are
+// never rendered; use the options to redact other volatile values.
+//
+// Run the tests with [UpdateGoldenEnv] set to write the current output to
+// path instead.
+func (l *Logger) AssertGolden(t assert.TestingT, path string, opts ...GoldenOption) bool {
+	if h, ok := t.(interface{ Helper() }); ok {
+		h.Helper()
+	}
+	cfg := goldenConfig{keys: map[string]bool{}}
+	for _, opt := range opts {
+		opt(&cfg)
+	}
+	got, err := cfg.render(l.recorded.All())
+	if err != nil {
+		return assert.Fail(t, "render log entries: "+err.Error())
+	}
+	if os.Getenv(UpdateGoldenEnv) != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o750); err != nil {
+			return assert.Fail(t, "create golden directory: "+err.Error())
+		}
+		if err := os.WriteFile(path, got, 0o600); err != nil {
+			return assert.Fail(t, "write golden file: "+err.Error())
+		}
+		return true
+	}
+	want, err := os.ReadFile(path) // #nosec G304 -- path is chosen by the test.
+	if err != nil {
+		return assert.Fail(t, "read golden file: "+err.Error())
+	}
+	return assert.Equal(t, string(want), string(got))
+}
+
+func (c *goldenConfig) render(entries []observer.LoggedEntry) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := json.NewEncoder(&buf)
+	enc.SetEscapeHTML(false)
+	for _, entry := range entries {
+		if err := enc.Encode(c.normalize(entry)); err != nil {
+			return nil, err
+		}
+	}
+	return buf.Bytes(), nil
+}
+
+func (c *goldenConfig) normalize(entry observer.LoggedEntry) map[string]any {
+	m := entryMap(entry)
+	for _, field := range entry.Context {
+		if c.keys[field.Key] || (c.times && isTime(field)) {
+			m[field.Key] = redacted
+		}
+	}
+	for key, value := range m {
+		m[key] = c.redactPatterns(value)
+	}
+	return m
+}
+
+func (c *goldenConfig) redactPatterns(value any) any {
+	s, ok := value.(string)
+	if !ok {
+		return value
+	}
+	for _, pattern := range c.patterns {
+		s = pattern.ReplaceAllString(s, redacted)
+	}
+	return s
+}
+
+func isTime(field zapcore.Field) bool {
+	return field.Type == zapcore.TimeType || field.Type == zapcore.TimeFullType
+}
+
+// entryMap renders entry as the map of its level, message and fields.
+func entryMap(entry observer.LoggedEntry) map[string]any {
+	m := entry.ContextMap()
+	m["level"] = entry.Level.String()
+	m["msg"] = entry.Message
+	return m
+}
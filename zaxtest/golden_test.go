@@ -0,0 +1,44 @@
+package zaxtest
+
+import (
+	"path/filepath"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestAssertGolden(t *testing.T) {
+	logger := NewLogger(t)
+	logger.GetZapLogger().Info("request started",
+		zap.String("request_id", "req-8f14e45f"),
+		zap.Time("started_at", time.Now()),
+		zap.String("session", "volatile"),
+	)
+	logger.GetZapLogger().Warn("request failed for req-8f14e45f", zap.Int("attempt", 2))
+
+	assert.True(t, logger.AssertGolden(t, filepath.Join("testdata", "golden.jsonl"),
+		RedactPattern(regexp.MustCompile(`req-[0-9a-f]+`)),
+		RedactKeys("session"),
+		RedactTimes(),
+	))
+
+	t.Setenv(UpdateGoldenEnv, "")
+	mock := &mockT{}
+	logger.AssertGolden(mock, filepath.Join("testdata", "golden.jsonl"))
+	assert.Equal(t, 1, mock.failures)
+}
+
+func TestAssertGoldenUpdate(t *testing.T) {
+	t.Setenv(UpdateGoldenEnv, "1")
+	path := filepath.Join(t.TempDir(), "nested", "golden.jsonl")
+
+	logger := NewLogger(t)
+	logger.GetZapLogger().Info("message")
+	assert.True(t, logger.AssertGolden(t, path))
+
+	t.Setenv(UpdateGoldenEnv, "")
+	assert.True(t, logger.AssertGolden(t, path))
+}
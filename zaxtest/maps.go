@@ -0,0 +1,21 @@
+package zaxtest
+
+import "go.uber.org/zap/zaptest/observer"
+
+// EntriesAsMaps converts the entries observed by obs into maps of their
+// fields, plus a "level" and a "msg" key, so table-driven tests can compare
+// them with assert.Contains, assert.Equal or cmp.Diff directly. Field values
+// are what zap would encode, e.g. int64 for zap.Int.
+func EntriesAsMaps(obs *observer.ObservedLogs) []map[string]any {
+	entries := obs.All()
+	maps := make([]map[string]any, len(entries))
+	for i, entry := range entries {
+		maps[i] = entryMap(entry)
+	}
+	return maps
+}
+
+// EntriesAsMaps converts the recorded entries into maps; see [EntriesAsMaps].
+func (l *Logger) EntriesAsMaps() []map[string]any {
+	return EntriesAsMaps(l.recorded)
+}
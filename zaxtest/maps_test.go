@@ -0,0 +1,29 @@
+package zaxtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+func TestEntriesAsMaps(t *testing.T) {
+	core, obs := observer.New(zapcore.DebugLevel)
+	logger := zap.New(core)
+	logger.Info("first", zap.String("trace_id", "trace"), zap.Int("attempt", 1))
+	logger.Debug("second")
+
+	assert.Equal(t, []map[string]any{
+		{"level": "info", "msg": "first", "trace_id": "trace", "attempt": int64(1)},
+		{"level": "debug", "msg": "second"},
+	}, EntriesAsMaps(obs))
+}
+
+func TestLoggerEntriesAsMaps(t *testing.T) {
+	logger := NewLogger(t)
+	logger.GetZapLogger().Warn("message", zap.Bool("retry", true))
+
+	assert.Contains(t, logger.EntriesAsMaps(), map[string]any{"level": "warn", "msg": "message", "retry": true})
+}
@@ -0,0 +1,147 @@
+package zaxtest
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// EntryMatcher matches a single recorded entry.
+type EntryMatcher interface {
+	// Match reports whether entry matches.
+	Match(entry observer.LoggedEntry) bool
+	// String describes what is matched, for failure messages.
+	String() string
+}
+
+type entryMatcher struct {
+	description string
+	match       func(observer.LoggedEntry) bool
+}
+
+func (m entryMatcher) Match(entry observer.LoggedEntry) bool { return m.match(entry) }
+func (m entryMatcher) String() string                        { return m.description }
+
+// Message matches entries logged with msg.
+func Message(msg string) EntryMatcher {
+	return entryMatcher{
+		description: fmt.Sprintf("message %q", msg),
+		match:       func(entry observer.LoggedEntry) bool { return entry.Message == msg },
+	}
+}
+
+// Level matches entries logged at level.
+func Level(level zapcore.Level) EntryMatcher {
+	return entryMatcher{
+		description: fmt.Sprintf("level %s", level),
+		match:       func(entry observer.LoggedEntry) bool { return entry.Level == level },
+	}
+}
+
+// Field matches entries having a field equal to field, comparing its type as
+// well as its value.
+func Field(field zap.Field) EntryMatcher {
+	return entryMatcher{
+		description: fmt.Sprintf("field %s=%v", field.Key, fieldValue(field)),
+		match: func(entry observer.LoggedEntry) bool {
+			for _, f := range entry.Context {
+				if f.Equals(field) {
+					return true
+				}
+			}
+			return false
+		},
+	}
+}
+
+// FieldValue matches entries having a field with key and value, typed the
+// way [zap.Any] would type value; e.g. FieldValue("attempt", 3) matches
+// zap.Int("attempt", 3) and FieldValue("elapsed", time.Second) matches
+// zap.Duration("elapsed", time.Second).
+func FieldValue(key string, value any) EntryMatcher {
+	return Field(zap.Any(key, value))
+}
+
+// HasKey matches entries having a field with key, whatever its value.
+func HasKey(key string) EntryMatcher {
+	return entryMatcher{
+		description: fmt.Sprintf("key %s", key),
+		match:       func(entry observer.LoggedEntry) bool { return hasKey(entry, key) },
+	}
+}
+
+// NoKey matches entries without any field with key.
+func NoKey(key string) EntryMatcher {
+	return entryMatcher{
+		description: fmt.Sprintf("no key %s", key),
+		match:       func(entry observer.LoggedEntry) bool { return !hasKey(entry, key) },
+	}
+}
+
+// AssertEntry asserts that a single recorded entry matches all of matchers.
+func (l *Logger) AssertEntry(t assert.TestingT, matchers ...EntryMatcher) bool {
+	if l.findEntry(matchers) {
+		return true
+	}
+	return assert.Fail(t, "no log entry matches "+describe(matchers))
+}
+
+// AssertNoEntry asserts that no recorded entry matches all of matchers.
+func (l *Logger) AssertNoEntry(t assert.TestingT, matchers ...EntryMatcher) bool {
+	if !l.findEntry(matchers) {
+		return true
+	}
+	return assert.Fail(t, "a log entry matches "+describe(matchers))
+}
+
+// AssertLogEntryKeyAbsent asserts that no recorded entry has a field with
+// key.
+func (l *Logger) AssertLogEntryKeyAbsent(t assert.TestingT, key string) bool {
+	return l.AssertNoEntry(t, HasKey(key))
+}
+
+func (l *Logger) findEntry(matchers []EntryMatcher) bool {
+	for _, entry := range l.recorded.All() {
+		if matchAll(entry, matchers) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchAll(entry observer.LoggedEntry, matchers []EntryMatcher) bool {
+	for _, matcher := range matchers {
+		if !matcher.Match(entry) {
+			return false
+		}
+	}
+	return true
+}
+
+func hasKey(entry observer.LoggedEntry, key string) bool {
+	for _, f := range entry.Context {
+		if f.Key == key {
+			return true
+		}
+	}
+	return false
+}
+
+func describe(matchers []EntryMatcher) string {
+	descriptions := make([]string, len(matchers))
+	for i, matcher := range matchers {
+		descriptions[i] = matcher.String()
+	}
+	return "[" + strings.Join(descriptions, ", ") + "]"
+}
+
+// fieldValue is the value zap would encode for field.
+func fieldValue(field zap.Field) any {
+	enc := zapcore.NewMapObjectEncoder()
+	field.AddTo(enc)
+	return enc.Fields[field.Key]
+}
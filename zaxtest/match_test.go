@@ -0,0 +1,35 @@
+package zaxtest
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+func TestAssertEntry(t *testing.T) {
+	logger := NewLogger(t)
+	logger.GetZapLogger().Info("first", zap.String("trace_id", "trace"), zap.Int("attempt", 1))
+	logger.GetZapLogger().Warn("second", zap.Bool("retry", true), zap.Duration("elapsed", time.Second))
+
+	assert.True(t, logger.AssertEntry(t, Message("first"), Level(zapcore.InfoLevel), FieldValue("attempt", 1)))
+	assert.True(t, logger.AssertEntry(t, Message("second"), FieldValue("retry", true), FieldValue("elapsed", time.Second)))
+	assert.True(t, logger.AssertEntry(t, HasKey("trace_id"), NoKey("retry")))
+	assert.True(t, logger.AssertNoEntry(t, FieldValue("attempt", "1")))
+	assert.True(t, logger.AssertLogEntryKeyAbsent(t, "user_id"))
+
+	mock := &mockT{}
+	// Both fields are logged, but never on the same entry.
+	assert.False(t, logger.AssertEntry(mock, FieldValue("attempt", 1), FieldValue("retry", true)))
+	assert.False(t, logger.AssertNoEntry(mock, Level(zapcore.WarnLevel)))
+	assert.False(t, logger.AssertLogEntryKeyAbsent(mock, "trace_id"))
+	assert.Equal(t, 3, mock.failures)
+}
+
+func TestMatcherString(t *testing.T) {
+	assert.Equal(t, "[message \"msg\", level warn, field attempt=3, key a, no key b]", describe([]EntryMatcher{
+		Message("msg"), Level(zapcore.WarnLevel), FieldValue("attempt", 3), HasKey("a"), NoKey("b"),
+	}))
+}
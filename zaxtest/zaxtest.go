@@ -0,0 +1,69 @@
+// Package zaxtest provides a recording zap logger and assertions for testing
+// code that logs with zax fields.
+package zaxtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+)
+
+// Logger is a zap logger that records every entry, at any level, so tests
+// can assert on what was logged.
+type Logger struct {
+	logger   *zap.Logger
+	recorded *observer.ObservedLogs
+}
+
+// NewLogger returns a Logger recording entries of all levels.
+func NewLogger(t testing.TB) *Logger {
+	t.Helper()
+	core, recorded := observer.New(zapcore.DebugLevel)
+	return &Logger{
+		logger:   zap.New(core),
+		recorded: recorded,
+	}
+}
+
+// GetZapLogger returns the recording zap logger.
+func (l *Logger) GetZapLogger() *zap.Logger {
+	return l.logger
+}
+
+// GetRecordedLogs returns all entries recorded so far.
+func (l *Logger) GetRecordedLogs() []observer.LoggedEntry {
+	return l.recorded.All()
+}
+
+// AssertLogEntryExist asserts that some recorded entry has a string field
+// with the given key and value. An empty key and value always match.
+func (l *Logger) AssertLogEntryExist(t assert.TestingT, key, value string) bool {
+	for _, log := range l.recorded.All() {
+		for _, r := range log.Context {
+			if r.Key == key && r.String == value {
+				return true
+			}
+		}
+	}
+	if key == "" && value == "" {
+		return true
+	}
+	return assert.Fail(t, fmt.Sprintf("log entry does not exist with, %s = %s", key, value))
+}
+
+// AssertLogEntryKeyExist asserts that some recorded entry has a field with
+// the given key.
+func (l *Logger) AssertLogEntryKeyExist(t assert.TestingT, key string) bool {
+	for _, log := range l.recorded.All() {
+		for _, r := range log.Context {
+			if r.Key == key {
+				return true
+			}
+		}
+	}
+	return assert.Fail(t, fmt.Sprintf("log entry does not exist with key = %s ", key))
+}
@@ -0,0 +1,32 @@
+package zaxtest
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"go.uber.org/zap"
+)
+
+func TestLogger(t *testing.T) {
+	logger := NewLogger(t)
+	logger.GetZapLogger().Info("record", zap.String("trace_id", "trace"))
+
+	assert.Len(t, logger.GetRecordedLogs(), 1)
+	assert.True(t, logger.AssertLogEntryExist(t, "trace_id", "trace"))
+	assert.True(t, logger.AssertLogEntryExist(t, "", ""))
+	assert.True(t, logger.AssertLogEntryKeyExist(t, "trace_id"))
+
+	mock := &mockT{}
+	assert.False(t, logger.AssertLogEntryExist(mock, "trace_id", "other"))
+	assert.False(t, logger.AssertLogEntryKeyExist(mock, "span_id"))
+	assert.Equal(t, 2, mock.failures)
+}
+
+// mockT records assertion failures instead of failing the test.
+type mockT struct {
+	failures int
+}
+
+func (m *mockT) Errorf(string, ...interface{}) {
+	m.failures++
+}
@@ -0,0 +1,54 @@
+// Package zaxtwirp provides Twirp server hooks populating zax fields and
+// logging errors with them.
+package zaxtwirp
+
+import (
+	"context"
+
+	"github.com/twitchtv/twirp"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields set by the hooks.
+const (
+	ServiceKey = "twirp_service"
+	MethodKey  = "twirp_method"
+)
+
+// ServerHooks returns hooks appending the fully qualified service name and a
+// request ID, kept if already set and generated otherwise (see
+// [zax.EnsureID]), to the zax fields when a request is received, and the
+// method name once it is routed. Errors are logged through logger with the
+// fields of the request:
+//
+//	server := example.NewHaberdasherServer(impl, twirp.WithServerHooks(zaxtwirp.ServerHooks(logger)))
+func ServerHooks(logger *zap.Logger) *twirp.ServerHooks {
+	return &twirp.ServerHooks{
+		RequestReceived: func(ctx context.Context) (context.Context, error) {
+			ctx, _ = zax.EnsureID(ctx, zax.RequestIDKey.Name(), nil)
+			return zax.Append(ctx, []zap.Field{zap.String(ServiceKey, serviceName(ctx))}), nil
+		},
+		RequestRouted: func(ctx context.Context) (context.Context, error) {
+			method, _ := twirp.MethodName(ctx)
+			return zax.Append(ctx, []zap.Field{zap.String(MethodKey, method)}), nil
+		},
+		Error: func(ctx context.Context, err twirp.Error) context.Context {
+			zax.Logger(ctx, logger).Error("twirp error",
+				zap.String("code", string(err.Code())),
+				zap.String("error", err.Msg()),
+				zap.Any("meta", err.MetaMap()),
+			)
+			return ctx
+		},
+	}
+}
+
+// serviceName returns the service of ctx, qualified by its package.
+func serviceName(ctx context.Context) string {
+	service, _ := twirp.ServiceName(ctx)
+	if pkg, ok := twirp.PackageName(ctx); ok && pkg != "" {
+		return pkg + "." + service
+	}
+	return service
+}
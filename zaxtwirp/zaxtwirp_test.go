@@ -0,0 +1,50 @@
+//go:build !zax_disabled
+
+package zaxtwirp
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/twitchtv/twirp"
+	"github.com/twitchtv/twirp/ctxsetters"
+	"github.com/yuseferi/zax/v2"
+	"github.com/yuseferi/zax/v2/zaxtest"
+	"go.uber.org/zap"
+)
+
+func TestServerHooks(t *testing.T) {
+	logs := zaxtest.NewLogger(t)
+	hooks := ServerHooks(logs.GetZapLogger())
+
+	ctx := ctxsetters.WithPackageName(context.Background(), "example")
+	ctx = ctxsetters.WithServiceName(ctx, "Haberdasher")
+	ctx = zax.SetRequestID(ctx, "request")
+	ctx, err := hooks.RequestReceived(ctx)
+	require.NoError(t, err)
+	ctx = ctxsetters.WithMethodName(ctx, "MakeHat")
+	ctx, err = hooks.RequestRouted(ctx)
+	require.NoError(t, err)
+
+	assert.Equal(t, []zap.Field{
+		zap.String(MethodKey, "MakeHat"),
+		zap.String(ServiceKey, "example.Haberdasher"),
+		zap.String("request_id", "request"),
+	}, zax.GetAll(ctx))
+
+	hooks.Error(ctx, twirp.InvalidArgumentError("size", "must be positive"))
+	entries := logs.GetRecordedLogs()
+	require.Len(t, entries, 1)
+	fields := entries[0].ContextMap()
+	assert.Equal(t, "invalid_argument", fields["code"])
+	assert.Equal(t, "MakeHat", fields[MethodKey])
+	assert.Equal(t, "request", fields["request_id"])
+}
+
+func TestServerHooksRequestID(t *testing.T) {
+	ctx, err := ServerHooks(zap.NewNop()).RequestReceived(context.Background())
+	require.NoError(t, err)
+	assert.NotEmpty(t, zax.RequestID(ctx))
+}
@@ -0,0 +1,60 @@
+// Package zaxws scopes zax fields to WebSocket connections and the messages
+// exchanged over them. It only depends on net/http, so it fits any WebSocket
+// library: call [NewConn] once the connection is upgraded, and derive a
+// context per message with [Conn.Message].
+package zaxws
+
+import (
+	"context"
+	"net/http"
+	"sync/atomic"
+
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+// Keys of the fields describing connections and messages.
+const (
+	ConnIDKey      = "ws_conn_id"
+	SubprotocolKey = "ws_subprotocol"
+	RemoteAddrKey  = "ws_remote_addr"
+	SequenceKey    = "ws_seq"
+)
+
+// Conn holds the fields of a WebSocket connection. It is safe for concurrent
+// use, so reader and writer goroutines can share it.
+type Conn struct {
+	ctx context.Context
+	seq atomic.Uint64
+}
+
+// NewConn returns the connection upgraded from r, with a context holding the
+// fields of r, a new connection ID (see [zax.UUIDv7]), the negotiated
+// subprotocol, if any, and the remote address:
+//
+//	ws, err := upgrader.Upgrade(w, r, nil)
+//	if err != nil {
+//		return
+//	}
+//	conn := zaxws.NewConn(r, ws.Subprotocol())
+func NewConn(r *http.Request, subprotocol string) *Conn {
+	fields := make([]zap.Field, 0, 3)
+	fields = append(fields, zap.String(ConnIDKey, zax.UUIDv7()))
+	if subprotocol != "" {
+		fields = append(fields, zap.String(SubprotocolKey, subprotocol))
+	}
+	fields = append(fields, zap.String(RemoteAddrKey, r.RemoteAddr))
+	return &Conn{ctx: zax.Append(r.Context(), fields)}
+}
+
+// Context returns the context of the connection. It is done when the
+// upgraded request is, which depends on the WebSocket library.
+func (c *Conn) Context() context.Context {
+	return c.ctx
+}
+
+// Message returns the context of the next message of the connection: its
+// context along with a sequence field, numbering messages from 1.
+func (c *Conn) Message() context.Context {
+	return zax.Append(c.ctx, []zap.Field{zap.Uint64(SequenceKey, c.seq.Add(1))})
+}
@@ -0,0 +1,44 @@
+//go:build !zax_disabled
+
+package zaxws
+
+import (
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/yuseferi/zax/v2"
+	"go.uber.org/zap"
+)
+
+func TestConn(t *testing.T) {
+	r := httptest.NewRequest("GET", "/ws", nil)
+	r = r.WithContext(zax.Set(context.Background(), []zap.Field{zap.String("trace_id", "abc")}))
+	conn := NewConn(r, "graphql-ws")
+
+	connID, ok := zax.GetField(conn.Context(), ConnIDKey)
+	require.True(t, ok)
+	assert.NotEmpty(t, connID.String)
+	assert.Equal(t, []zap.Field{
+		zap.String(ConnIDKey, connID.String),
+		zap.String(SubprotocolKey, "graphql-ws"),
+		zap.String(RemoteAddrKey, r.RemoteAddr),
+		zap.String("trace_id", "abc"),
+	}, zax.GetAll(conn.Context()))
+
+	first, second := conn.Message(), conn.Message()
+	seq, _ := zax.GetField(first, SequenceKey)
+	assert.Equal(t, zap.Uint64(SequenceKey, 1), seq)
+	seq, _ = zax.GetField(second, SequenceKey)
+	assert.Equal(t, zap.Uint64(SequenceKey, 2), seq)
+	_, ok = zax.GetField(conn.Context(), SequenceKey)
+	assert.False(t, ok, "the connection context has no sequence")
+}
+
+func TestConnWithoutSubprotocol(t *testing.T) {
+	conn := NewConn(httptest.NewRequest("GET", "/ws", nil), "")
+	_, ok := zax.GetField(conn.Context(), SubprotocolKey)
+	assert.False(t, ok)
+}